@@ -0,0 +1,133 @@
+// Package accesslog 解析 S3 服务器访问日志，并汇总为可在前端展示的报告
+package accesslog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout 日志中请求时间的格式，如 [06/Feb/2019:00:00:38 +0000]
+const timeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// Entry 一条访问日志记录，缺失的字段("-")为空字符串或 0
+type Entry struct {
+	BucketOwner string
+	Bucket      string
+	Time        time.Time
+	RemoteIP    string
+	Requester   string
+	RequestID   string
+	Operation   string // 如 REST.GET.OBJECT
+	Key         string
+	RequestURI  string
+	Status      int
+	ErrorCode   string
+	BytesSent   int64
+	ObjectSize  int64
+	TotalTime   int64 // 毫秒
+	Referer     string
+	UserAgent   string
+	VersionID   string
+}
+
+// ParseLine 解析一行访问日志。至少需要前 17 个字段，新版日志追加的字段会被忽略
+func ParseLine(line string) (Entry, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(fields) < 17 {
+		return Entry{}, fmt.Errorf("expected at least 17 fields, got %d", len(fields))
+	}
+
+	var e Entry
+	e.BucketOwner = value(fields[0])
+	e.Bucket = value(fields[1])
+	if e.Time, err = time.Parse(timeLayout, fields[2]); err != nil {
+		return Entry{}, fmt.Errorf("invalid time %q", fields[2])
+	}
+	e.RemoteIP = value(fields[3])
+	e.Requester = value(fields[4])
+	e.RequestID = value(fields[5])
+	e.Operation = value(fields[6])
+	e.Key = value(fields[7])
+	e.RequestURI = value(fields[8])
+	if e.Status, err = number(fields[9]); err != nil {
+		return Entry{}, fmt.Errorf("invalid HTTP status %q", fields[9])
+	}
+	e.ErrorCode = value(fields[10])
+	if e.BytesSent, err = number64(fields[11]); err != nil {
+		return Entry{}, fmt.Errorf("invalid bytes sent %q", fields[11])
+	}
+	if e.ObjectSize, err = number64(fields[12]); err != nil {
+		return Entry{}, fmt.Errorf("invalid object size %q", fields[12])
+	}
+	if e.TotalTime, err = number64(fields[13]); err != nil {
+		return Entry{}, fmt.Errorf("invalid total time %q", fields[13])
+	}
+	e.Referer = value(fields[15])
+	e.UserAgent = value(fields[16])
+	if len(fields) > 17 {
+		e.VersionID = value(fields[17])
+	}
+	return e, nil
+}
+
+// splitFields 按空格拆分字段，[...] 和 "..." 内的空格不拆分，并去掉外层括号和引号
+func splitFields(line string) ([]string, error) {
+	var fields []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' {
+			i++
+			continue
+		}
+		var end int
+		switch line[i] {
+		case '[':
+			end = strings.IndexByte(line[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ at column %d", i+1)
+			}
+			fields = append(fields, line[i+1:i+1+end])
+			i += end + 2
+		case '"':
+			end = strings.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at column %d", i+1)
+			}
+			fields = append(fields, line[i+1:i+1+end])
+			i += end + 2
+		default:
+			end = strings.IndexByte(line[i:], ' ')
+			if end < 0 {
+				end = len(line) - i
+			}
+			fields = append(fields, line[i:i+end])
+			i += end
+		}
+	}
+	return fields, nil
+}
+
+func value(field string) string {
+	if field == "-" {
+		return ""
+	}
+	return field
+}
+
+func number(field string) (int, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	return strconv.Atoi(field)
+}
+
+func number64(field string) (int64, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	return strconv.ParseInt(field, 10, 64)
+}
@@ -0,0 +1,149 @@
+package accesslog
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// topN 按键、请求者统计时报告中保留的条目数
+const topN = 20
+
+// Count 某一维度下的请求数和发送字节数
+type Count struct {
+	Name     string `json:"name"`     // 操作、对象键、请求者或状态码
+	Requests int64  `json:"requests"` // 请求数
+	Bytes    int64  `json:"bytes"`    // 发送字节数
+}
+
+// Report 访问日志汇总报告
+type Report struct {
+	LogObjects  int       `json:"logObjects"`  // 读取的日志对象数
+	FailedLogs  int       `json:"failedLogs"`  // 下载或读取失败而未汇总(或只汇总了一部分)的日志对象数
+	Lines       int64     `json:"lines"`       // 日志行数
+	Malformed   int64     `json:"malformed"`   // 无法解析的行数
+	Requests    int64     `json:"requests"`    // 请求总数
+	Errors      int64     `json:"errors"`      // 状态码 >= 400 的请求数
+	BytesSent   int64     `json:"bytesSent"`   // 发送字节总数
+	Start       time.Time `json:"start"`       // 最早的请求时间
+	End         time.Time `json:"end"`         // 最晚的请求时间
+	ByOperation []Count   `json:"byOperation"` // 按操作统计
+	ByStatus    []Count   `json:"byStatus"`    // 按 HTTP 状态码统计
+	ByKey       []Count   `json:"byKey"`       // 请求最多的对象键(前 20)
+	ByRequester []Count   `json:"byRequester"` // 请求最多的请求者(前 20)
+}
+
+// Aggregator 逐条汇总访问日志，非并发安全
+type Aggregator struct {
+	report      Report
+	byOperation map[string]*Count
+	byStatus    map[string]*Count
+	byKey       map[string]*Count
+	byRequester map[string]*Count
+}
+
+// NewAggregator 创建空的汇总器
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		byOperation: make(map[string]*Count),
+		byStatus:    make(map[string]*Count),
+		byKey:       make(map[string]*Count),
+		byRequester: make(map[string]*Count),
+	}
+}
+
+// Add 汇总一条记录
+func (a *Aggregator) Add(e Entry) {
+	r := &a.report
+	r.Requests++
+	r.BytesSent += e.BytesSent
+	if e.Status >= 400 {
+		r.Errors++
+	}
+	if r.Start.IsZero() || e.Time.Before(r.Start) {
+		r.Start = e.Time
+	}
+	if e.Time.After(r.End) {
+		r.End = e.Time
+	}
+
+	requester := e.Requester
+	if requester == "" {
+		requester = "anonymous"
+	}
+	bump(a.byOperation, e.Operation, e.BytesSent)
+	bump(a.byStatus, strconv.Itoa(e.Status), e.BytesSent)
+	bump(a.byRequester, requester, e.BytesSent)
+	if e.Key != "" {
+		bump(a.byKey, e.Key, e.BytesSent)
+	}
+}
+
+// AddLog 读取一个日志对象的全部行，返回解析失败的行数
+func (a *Aggregator) AddLog(r io.Reader) (int64, error) {
+	a.report.LogObjects++
+	var malformed int64
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		a.report.Lines++
+		entry, err := ParseLine(line)
+		if err != nil {
+			malformed++
+			continue
+		}
+		a.Add(entry)
+	}
+	a.report.Malformed += malformed
+	return malformed, scanner.Err()
+}
+
+// AddFailed 记录一个下载或读取失败的日志对象
+func (a *Aggregator) AddFailed() {
+	a.report.FailedLogs++
+}
+
+// Report 返回当前的汇总结果
+func (a *Aggregator) Report() Report {
+	r := a.report
+	r.ByOperation = sorted(a.byOperation, 0)
+	r.ByStatus = sorted(a.byStatus, 0)
+	r.ByKey = sorted(a.byKey, topN)
+	r.ByRequester = sorted(a.byRequester, topN)
+	return r
+}
+
+func bump(counts map[string]*Count, name string, bytes int64) {
+	c, ok := counts[name]
+	if !ok {
+		c = &Count{Name: name}
+		counts[name] = c
+	}
+	c.Requests++
+	c.Bytes += bytes
+}
+
+// sorted 按请求数从多到少排序，limit 大于 0 时只保留前 limit 项
+func sorted(counts map[string]*Count, limit int) []Count {
+	list := make([]Count, 0, len(counts))
+	for _, c := range counts {
+		list = append(list, *c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Requests != list[j].Requests {
+			return list[i].Requests > list[j].Requests
+		}
+		return list[i].Name < list[j].Name
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
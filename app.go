@@ -10,13 +10,12 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// AWS SDK v1 imports
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr" // Import for AWS error handling
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	// Wails imports (unchanged)
@@ -42,259 +41,77 @@ func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 	// 导出上下文到全局变量以便获取上下文
 	ContextX = a.ctx
+	if err := initConfigPath(); err != nil {
+		runtime.LogError(ContextX, "Failed to prepare config file: "+err.Error())
+	}
 }
 
 // S3Manager struct using AWS SDK v1 S3 client
 type S3Manager struct {
-	client *s3.S3 // Changed from v2 s3.Client to v1 s3.S3
+	client *s3.S3     // Changed from v2 s3.Client to v1 s3.S3
+	node   nodes.Node // Node the client was built for
 }
 
-// NewS3Client connects to object storage using AWS SDK v1
-func (a *S3Manager) NewS3Client(endpoint, region, accessKey, secretKey string) (*S3Manager, error) {
-	ctx := context.Background() // Use background context for initial connection setup
-	runtime.LogDebug(ContextX, "Attempting to connect using v1 SDK: "+endpoint)
-
-	// Configure AWS session for v1
-	awsCfg := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:         aws.String(endpoint),
-		Region:           aws.String(region),
-		S3ForcePathStyle: aws.Bool(true), // Use path-style addressing, common for non-AWS S3
-		// DisableSSL:      aws.Bool(true), // Uncomment if endpoint uses HTTP instead of HTTPS
-	}
-
-	// Create a new session
-	sess, err := session.NewSession(awsCfg)
+// NewS3Client returns a manager backed by the cached client for the saved node
+// with the given ID. Credentials are resolved here and never leave the Go side.
+// The session is built on first use and reused afterwards, so no connection
+// check is made here; the first real request surfaces any error.
+func (a *S3Manager) NewS3Client(nodeID string) (*S3Manager, error) {
+	node, err := resolveNode(nodeID)
 	if err != nil {
-		runtime.LogError(ContextX, "v1: Failed to create session: "+err.Error())
-		return nil, fmt.Errorf("v1: unable to create AWS session: %v", err)
+		return nil, err
 	}
-	runtime.LogDebug(ContextX, "v1: Session created successfully for region: "+region)
-
-	// Create S3 service client
-	client := s3.New(sess)
-	runtime.LogDebug(ContextX, "v1: S3 client created")
-
-	// List buckets to verify connection (using WithContext variant)
-	listInput := &s3.ListBucketsInput{}
-	resp, err := client.ListBucketsWithContext(ctx, listInput)
+	client, err := s3Clients.Get(node)
 	if err != nil {
-		runtime.LogError(ContextX, "v1: Failed to list buckets: "+err.Error())
-		// Log AWS specific error details if available
-		if aerr, ok := err.(awserr.Error); ok {
-			runtime.LogError(ContextX, fmt.Sprintf("v1: AWS Error Code: %s, Message: %s", aerr.Code(), aerr.Message()))
-		}
-		return nil, fmt.Errorf("v1: failed to list buckets: %v", err)
-	}
-
-	runtime.LogDebug(ContextX, "v1: === S3 Buckets ===")
-	for _, bucket := range resp.Buckets {
-		if bucket.Name != nil && bucket.CreationDate != nil {
-			logMsg := fmt.Sprintf("- %s (Created at: %v)\n", *bucket.Name, *bucket.CreationDate)
-			runtime.LogDebug(ContextX, logMsg)
-			fmt.Printf(logMsg) // Also print to console as before
-		}
+		return nil, err
 	}
-
-	return &S3Manager{client: client}, nil
+	return &S3Manager{client: client, node: node}, nil
 }
 
-// GetAllS3NodesInfo remains the same as it doesn't interact with AWS SDK
+// GetAllS3NodesInfo returns every saved node with its secrets redacted
 func (a *S3Manager) GetAllS3NodesInfo() []nodes.Node {
-	fileContent, err := os.ReadFile("./config.json")
-	runtime.LogDebug(ContextX, string(fileContent))
+	nodeList, err := loadNodes()
 	if err != nil {
 		runtime.LogError(ContextX, err.Error())
 		return nil
 	}
-	nodeList, err := nodes.GetNodes(fileContent)
-	if err != nil {
-		runtime.LogError(ContextX, err.Error())
-		return nil
+	for i := range nodeList {
+		nodeList[i] = nodeList[i].Redacted()
 	}
-	runtime.LogDebug(ContextX, "成功获取节点信息"+nodeList[0].NodeName)
+	runtime.LogDebug(ContextX, fmt.Sprintf("成功获取节点信息，共 %d 个节点", len(nodeList)))
 	return nodeList
 }
 
-// GetNodeBucketInfo Get bucket information for a node using provided parameters (v1 SDK)
-func (a *S3Manager) GetNodeBucketInfo(endpoint, accessKey, secretKey, region string) []nodes.NodeBucketInfo {
-	runtime.LogDebug(ContextX, "v1: Getting node bucket info with provided parameters")
-
-	var allNodesBucketInfo []nodes.NodeBucketInfo
+// GetNodeBucketInfo Get bucket information for a saved node (v1 SDK).
+// Buckets are probed in parallel; CancelNodeBucketInfo stops a running call early.
+func (a *S3Manager) GetNodeBucketInfo(nodeID string) []nodes.NodeBucketInfo {
+	runtime.LogDebug(ContextX, "v1: Getting node bucket info for node "+nodeID)
 
-	s3Manager, err := a.NewS3Client(endpoint, region, accessKey, secretKey)
+	s3Manager, err := a.NewS3Client(nodeID)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Connection failed: "+err.Error())
 		return nil // Return empty slice on connection failure
 	}
 
-	nodeBucketInfo := nodes.NodeBucketInfo{
-		NodeName: "S3节点", // Default name as before
-		EndPoint: endpoint,
-		Buckets:  []nodes.BucketInfo{},
-	}
+	ctx, done := startBucketScan(nodeID)
+	defer done()
 
-	ctx := context.Background()
-	listInput := &s3.ListBucketsInput{}
-	resp, err := s3Manager.client.ListBucketsWithContext(ctx, listInput)
+	nodeBucketInfo, err := s3Manager.collectBucketInfo(ctx, nil)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Failed to list buckets: "+err.Error())
 		return nil // Return empty slice if listing fails
 	}
-
-	for _, bucket := range resp.Buckets {
-		if bucket.Name == nil || bucket.CreationDate == nil {
-			runtime.LogDebug(ContextX, "v1: Skipping bucket with nil name or creation date")
-			continue
-		}
-
-		bucketInfo := nodes.BucketInfo{
-			Name:                *bucket.Name,
-			CreationDate:        *bucket.CreationDate,
-			UsedSpace:           0,
-			TotalObjects:        0,
-			VersioningEnabled:   false,
-			PublicAccessBlocked: false,
-			HasPolicy:           false,
-			EncryptionEnabled:   false,
-			EncryptionType:      "",
-			HasLifecycleRules:   false,
-			LifecycleRulesCount: 0,
-			Region:              region, // Use the provided region
-			WebsiteEnabled:      false,
-		}
-
-		// Get object count and used space using pagination
-		listObjectsInput := &s3.ListObjectsV2Input{Bucket: bucket.Name}
-		err := s3Manager.client.ListObjectsV2PagesWithContext(ctx, listObjectsInput,
-			func(page *s3.ListObjectsV2Output, lastPage bool) bool {
-				if page == nil {
-					return false // Stop if page is nil
-				}
-				bucketInfo.TotalObjects += int64(len(page.Contents))
-				for _, obj := range page.Contents {
-					if obj.Size != nil {
-						bucketInfo.UsedSpace += *obj.Size
-					}
-				}
-				return !lastPage // Continue if not the last page
-			})
-		if err != nil {
-			runtime.LogError(ContextX, fmt.Sprintf("v1: Failed getting objects for bucket %s: %s", *bucket.Name, err.Error()))
-			// Continue to next bucket or property, depending on desired robustness
-		}
-
-		// Get Versioning status
-		verInput := &s3.GetBucketVersioningInput{Bucket: bucket.Name}
-		verOutput, err := s3Manager.client.GetBucketVersioningWithContext(ctx, verInput)
-		if err == nil && verOutput.Status != nil && *verOutput.Status == s3.BucketVersioningStatusEnabled {
-			bucketInfo.VersioningEnabled = true
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has versioning enabled", *bucket.Name))
-		} else if err != nil {
-			// Log non-critical errors
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get versioning for %s: %s", *bucket.Name, err.Error()))
-		}
-
-		// Get Public Access Block status
-		pubInput := &s3.GetPublicAccessBlockInput{Bucket: bucket.Name}
-		pubOutput, err := s3Manager.client.GetPublicAccessBlockWithContext(ctx, pubInput)
-		if err == nil && pubOutput.PublicAccessBlockConfiguration != nil {
-			config := pubOutput.PublicAccessBlockConfiguration
-			// Check if all block flags are true (using aws.BoolValue to safely dereference)
-			if aws.BoolValue(config.BlockPublicAcls) && aws.BoolValue(config.BlockPublicPolicy) &&
-				aws.BoolValue(config.IgnorePublicAcls) && aws.BoolValue(config.RestrictPublicBuckets) {
-				bucketInfo.PublicAccessBlocked = true
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has public access blocked", *bucket.Name))
-			}
-		} else if err != nil {
-			// Check if the error is because no configuration exists (expected case)
-			if aerr, ok := err.(awserr.Error); ok && aerr.Code() != "NoSuchPublicAccessBlockConfiguration" {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get public access block for %s: %s", *bucket.Name, err.Error()))
-			} else if !ok {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get public access block for %s: %s", *bucket.Name, err.Error()))
-			}
-		}
-
-		// Get Bucket Policy status
-		polInput := &s3.GetBucketPolicyInput{Bucket: bucket.Name}
-		_, err = s3Manager.client.GetBucketPolicyWithContext(ctx, polInput)
-		if err == nil {
-			bucketInfo.HasPolicy = true
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has a policy", *bucket.Name))
-		} else {
-			// Check if the error is NoSuchBucketPolicy (expected if no policy exists)
-			if aerr, ok := err.(awserr.Error); ok && aerr.Code() != "NoSuchBucketPolicy" {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get policy for %s: %s", *bucket.Name, err.Error()))
-			} else if !ok {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get policy for %s: %s", *bucket.Name, err.Error()))
-			}
-		}
-
-		// Get Encryption status
-		encInput := &s3.GetBucketEncryptionInput{Bucket: bucket.Name}
-		encOutput, err := s3Manager.client.GetBucketEncryptionWithContext(ctx, encInput)
-		if err == nil && encOutput.ServerSideEncryptionConfiguration != nil && len(encOutput.ServerSideEncryptionConfiguration.Rules) > 0 {
-			bucketInfo.EncryptionEnabled = true
-			// Get encryption type from the first rule
-			rule := encOutput.ServerSideEncryptionConfiguration.Rules[0]
-			if rule.ApplyServerSideEncryptionByDefault != nil && rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm != nil {
-				bucketInfo.EncryptionType = *rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm
-			}
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has encryption enabled, type: %s", *bucket.Name, bucketInfo.EncryptionType))
-		} else if err != nil {
-			// Check if the error is expected configuration not found error
-			if aerr, ok := err.(awserr.Error); ok && aerr.Code() != "NoSuchBucketPolicy" {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get encryption for %s: %s", *bucket.Name, err.Error()))
-			} else if !ok {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get encryption for %s: %s", *bucket.Name, err.Error()))
-			}
-		}
-
-		// Get Lifecycle rules status
-		lcInput := &s3.GetBucketLifecycleConfigurationInput{Bucket: bucket.Name}
-		lcOutput, err := s3Manager.client.GetBucketLifecycleConfigurationWithContext(ctx, lcInput)
-		if err == nil && len(lcOutput.Rules) > 0 {
-			bucketInfo.HasLifecycleRules = true
-			bucketInfo.LifecycleRulesCount = len(lcOutput.Rules)
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has %d lifecycle rules", *bucket.Name, bucketInfo.LifecycleRulesCount))
-		} else if err != nil {
-			// Check if the error is expected configuration not found error
-			if aerr, ok := err.(awserr.Error); ok && aerr.Code() != "NoSuchBucketPolicy" {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get lifecycle rules for %s: %s", *bucket.Name, err.Error()))
-			} else if !ok {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get lifecycle rules for %s: %s", *bucket.Name, err.Error()))
-			}
-		}
-
-		// Get Website hosting status
-		webInput := &s3.GetBucketWebsiteInput{Bucket: bucket.Name}
-		_, err = s3Manager.client.GetBucketWebsiteWithContext(ctx, webInput)
-		if err == nil {
-			bucketInfo.WebsiteEnabled = true
-			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s has static website hosting enabled", *bucket.Name))
-		} else if err != nil {
-			// Check if the error is expected configuration not found error
-			if aerr, ok := err.(awserr.Error); ok && aerr.Code() != "NoSuchBucketPolicy" {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get website config for %s: %s", *bucket.Name, err.Error()))
-			} else if !ok {
-				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get website config for %s: %s", *bucket.Name, err.Error()))
-			}
-		}
-
-		nodeBucketInfo.Buckets = append(nodeBucketInfo.Buckets, bucketInfo)
-		runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket info: %s, Objects: %d, Size: %d bytes",
-			bucketInfo.Name, bucketInfo.TotalObjects, bucketInfo.UsedSpace))
+	if nodeBucketInfo.Cancelled {
+		runtime.LogDebug(ContextX, "v1: Bucket info for node "+nodeID+" was cancelled, returning partial results")
 	}
 
-	allNodesBucketInfo = append(allNodesBucketInfo, nodeBucketInfo)
+	allNodesBucketInfo := []nodes.NodeBucketInfo{nodeBucketInfo}
 	jsonData, err := json.MarshalIndent(allNodesBucketInfo, "", "  ")
 	if err != nil {
 		runtime.LogError(ContextX, "v1: JSON serialization failed: "+err.Error())
 		// Still return the data collected so far
 		return allNodesBucketInfo
 	}
-	fmt.Println("v1: allNodesBucketInfo content:")
-	fmt.Println(string(jsonData))
 	runtime.LogDebug(ContextX, "v1: Complete node bucket info:\n"+string(jsonData))
 
 	return allNodesBucketInfo
@@ -302,18 +119,22 @@ func (a *S3Manager) GetNodeBucketInfo(endpoint, accessKey, secretKey, region str
 
 // ObjectInfo struct remains the same
 type ObjectInfo struct {
-	Key          string            `json:"key"`          // Object key
-	Size         int64             `json:"size"`         // Object size (bytes)
-	LastModified time.Time         `json:"lastModified"` // Last modified time
-	ETag         string            `json:"etag"`         // ETag value
-	ContentType  string            `json:"contentType"`  // Content type
-	StorageClass string            `json:"storageClass"` // Storage class
-	Metadata     map[string]string `json:"metadata"`     // User-defined metadata
-	VersionId    string            `json:"versionId"`    // Version ID (if versioning enabled)
+	Key             string            `json:"key"`                       // Object key
+	Size            int64             `json:"size"`                      // Object size (bytes)
+	LastModified    time.Time         `json:"lastModified"`              // Last modified time
+	ETag            string            `json:"etag"`                      // ETag value
+	ContentType     string            `json:"contentType"`               // Content type
+	StorageClass    string            `json:"storageClass"`              // Storage class
+	Metadata        map[string]string `json:"metadata"`                  // User-defined metadata
+	VersionId       string            `json:"versionId"`                 // Version ID (if versioning enabled)
+	Tags            []nodes.Tag       `json:"tags"`                      // Object tags (only when requested)
+	LockMode        string            `json:"lockMode"`                  // Object Lock retention mode (GOVERNANCE / COMPLIANCE)
+	RetainUntilDate *time.Time        `json:"retainUntilDate,omitempty"` // Object Lock retain-until date
+	LegalHold       bool              `json:"legalHold"`                 // Whether a legal hold is in effect
 }
 
 // UploadObject uploads a file to the specified bucket using AWS SDK v1
-func (a *S3Manager) UploadObject(endpoint, accessKey, secretKey, region, bucketName string) (string, error) {
+func (a *S3Manager) UploadObject(nodeID, bucketName string) (string, error) {
 	filePath := file.GetFilePath(ContextX)
 	if filePath == "" {
 		return "", fmt.Errorf("file selection cancelled or no file chosen")
@@ -325,20 +146,11 @@ func (a *S3Manager) UploadObject(endpoint, accessKey, secretKey, region, bucketN
 
 	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Uploading %s to bucket %s with key %s", filePath, bucketName, objectKey))
 
-	// Create S3 client specifically for this operation (could reuse if manager instance persists)
-	// Using the same configuration logic as NewS3Client
-	awsCfg := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:         aws.String(endpoint),
-		Region:           aws.String(region),
-		S3ForcePathStyle: aws.Bool(true),
-	}
-	sess, err := session.NewSession(awsCfg)
+	s3Manager, err := a.NewS3Client(nodeID)
 	if err != nil {
-		runtime.LogError(ContextX, "v1: Failed to create session for upload: "+err.Error())
-		return "", fmt.Errorf("v1: failed to create upload session: %v", err)
+		runtime.LogError(ContextX, "v1: Connection failed for UploadObject: "+err.Error())
+		return "", err
 	}
-	client := s3.New(sess)
 
 	// Open the file
 	fileHandle, err := os.Open(filePath)
@@ -388,7 +200,7 @@ func (a *S3Manager) UploadObject(endpoint, accessKey, secretKey, region, bucketN
 	runtime.LogDebug(ContextX, "v1: --------------------Uploading--------------------")
 	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket: %s, Key: %s, ContentType: %s", bucketName, objectKey, contentType))
 
-	result, err := client.PutObjectWithContext(ctx, putInput)
+	result, err := s3Manager.client.PutObjectWithContext(ctx, putInput)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Failed to upload file: "+err.Error())
 		if aerr, ok := err.(awserr.Error); ok {
@@ -406,7 +218,7 @@ func (a *S3Manager) UploadObject(endpoint, accessKey, secretKey, region, bucketN
 }
 
 // DownloadObject downloads an object from the specified bucket using AWS SDK v1
-func (a *S3Manager) DownloadObject(endpoint, accessKey, secretKey, region, bucketName, objectKey string) error {
+func (a *S3Manager) DownloadObject(nodeID, bucketName, objectKey string) error {
 	savePathDir := file.GetDirPath(ContextX)
 	if savePathDir == "" {
 		return fmt.Errorf("v1: save directory selection cancelled or failed")
@@ -421,19 +233,11 @@ func (a *S3Manager) DownloadObject(endpoint, accessKey, secretKey, region, bucke
 		return fmt.Errorf("v1: unable to create directory %s: %v", filepath.Dir(savePath), err)
 	}
 
-	// Create S3 client
-	awsCfg := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:         aws.String(endpoint),
-		Region:           aws.String(region),
-		S3ForcePathStyle: aws.Bool(true),
-	}
-	sess, err := session.NewSession(awsCfg)
+	s3Manager, err := a.NewS3Client(nodeID)
 	if err != nil {
-		runtime.LogError(ContextX, "v1: Failed to create session for download: "+err.Error())
-		return fmt.Errorf("v1: failed to create download session: %v", err)
+		runtime.LogError(ContextX, "v1: Connection failed for DownloadObject: "+err.Error())
+		return err
 	}
-	client := s3.New(sess)
 
 	// Create the output file
 	outFile, err := os.Create(savePath)
@@ -451,7 +255,7 @@ func (a *S3Manager) DownloadObject(endpoint, accessKey, secretKey, region, bucke
 
 	// Download the object
 	ctx := context.Background()
-	result, err := client.GetObjectWithContext(ctx, getInput)
+	result, err := s3Manager.client.GetObjectWithContext(ctx, getInput)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Failed to download object: "+err.Error())
 		if aerr, ok := err.(awserr.Error); ok {
@@ -476,18 +280,19 @@ func (a *S3Manager) DownloadObject(endpoint, accessKey, secretKey, region, bucke
 	return nil
 }
 
-// ListObjects retrieves object information from a bucket using AWS SDK v1
-func (a *S3Manager) ListObjects(endpoint, accessKey, secretKey, region, bucketName string) ([]ObjectInfo, error) {
+// ListObjects retrieves object information from a bucket using AWS SDK v1.
+// When withTags is true each object's tags are fetched as well, with bounded concurrency.
+func (a *S3Manager) ListObjects(nodeID, bucketName string, withTags bool) ([]ObjectInfo, error) {
 	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Listing objects in bucket %s", bucketName))
 
-	s3Manager, err := a.NewS3Client(endpoint, region, accessKey, secretKey)
+	s3Manager, err := a.NewS3Client(nodeID)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Connection failed for ListObjects: "+err.Error())
 		return nil, err
 	}
 
 	var objectInfoList []ObjectInfo
-	ctx := context.Background()
+	ctx := appContext()
 	listInput := &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)}
 
 	err = s3Manager.client.ListObjectsV2PagesWithContext(ctx, listInput,
@@ -539,15 +344,20 @@ func (a *S3Manager) ListObjects(endpoint, accessKey, secretKey, region, bucketNa
 		return nil, fmt.Errorf("v1: failed listing objects: %v", err)
 	}
 
+	// Object tags need one GetObjectTagging call per object, so only fetch them on request
+	if withTags {
+		s3Manager.fillObjectTags(ctx, bucketName, objectInfoList)
+	}
+
 	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Successfully listed %d objects in bucket %s", len(objectInfoList), bucketName))
 	return objectInfoList, nil
 }
 
 // GetObjectInfo retrieves detailed information for a single object using AWS SDK v1
-func (a *S3Manager) GetObjectInfo(endpoint, accessKey, secretKey, region, bucketName, objectKey string) (*ObjectInfo, error) {
+func (a *S3Manager) GetObjectInfo(nodeID, bucketName, objectKey string) (*ObjectInfo, error) {
 	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Getting info for object %s in bucket %s", objectKey, bucketName))
 
-	s3Manager, err := a.NewS3Client(endpoint, region, accessKey, secretKey)
+	s3Manager, err := a.NewS3Client(nodeID)
 	if err != nil {
 		runtime.LogError(ContextX, "v1: Connection failed for GetObjectInfo: "+err.Error())
 		return nil, err
@@ -577,6 +387,10 @@ func (a *S3Manager) GetObjectInfo(endpoint, accessKey, secretKey, region, bucket
 		ContentType:  aws.StringValue(result.ContentType),
 		StorageClass: aws.StringValue(result.StorageClass),
 		VersionId:    aws.StringValue(result.VersionId),
+		LockMode:     aws.StringValue(result.ObjectLockMode),
+		LegalHold:    aws.StringValue(result.ObjectLockLegalHoldStatus) == s3.ObjectLockLegalHoldStatusOn,
+		// Retain-until date stays nil when the object has no retention
+		RetainUntilDate: result.ObjectLockRetainUntilDate,
 	}
 
 	if result.Metadata != nil {
@@ -592,41 +406,19 @@ func (a *S3Manager) GetObjectInfo(endpoint, accessKey, secretKey, region, bucket
 	return objectInfo, nil
 }
 
-// AddNode remains the same as it doesn't interact with AWS SDK
-func (a *S3Manager) AddNode(name, endpoint, accessKey, secretKey, region string) bool {
-	runtime.LogDebug(ContextX, "Adding node (no SDK change)") // Added log clarification
-	fileContent, err := os.ReadFile("./config.json")
-	runtime.LogDebug(ContextX, string(fileContent))
-	if err != nil {
-		runtime.LogError(ContextX, err.Error())
-		return false
-	}
-	nodeList, err := nodes.GetNodes(fileContent)
-	if err != nil {
-		runtime.LogError(ContextX, "Failed to parse node list: "+err.Error())
-		return false
-	}
-
-	// Add the new node
-	newNode := nodes.Node{
-		NodeName:  name,
-		EndPoint:  endpoint,
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		Region:    region,
-	}
-	nodeList = nodes.AddNode(nodeList, newNode) // Assuming nodes.AddNode handles appending
-
-	// Write the updated list back to the file
-	updatedContent, err := json.MarshalIndent(nodeList, "", "    ")
-	if err != nil {
-		runtime.LogError(ContextX, "Failed to serialize node list: "+err.Error())
-		return false
-	}
-
-	err = os.WriteFile("./config.json", updatedContent, 0644)
+// AddNode 添加节点，node 可包含连接选项、凭证来源和 AssumeRole 设置；ID 由后端生成
+func (a *S3Manager) AddNode(node nodes.Node) bool {
+	runtime.LogDebug(ContextX, "Adding node")
+	node.ID = nodes.NewID()
+	node.NodeName = strings.TrimSpace(node.NodeName)
+	err := updateNodes(func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		if err := nodes.CheckName(nodeList, "", node.NodeName); err != nil {
+			return nil, err
+		}
+		return nodes.AddNode(nodeList, node), nil
+	})
 	if err != nil {
-		runtime.LogError(ContextX, "Failed to write config file: "+err.Error())
+		runtime.LogError(ContextX, "Failed to add node: "+err.Error())
 		return false
 	}
 
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// awsErrorCode returns the AWS error code of err, or "" if it isn't an AWS error
+func awsErrorCode(err error) string {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code()
+	}
+	return ""
+}
+
+// logAWSError logs msg with err, plus the AWS error code and message when available
+func logAWSError(msg string, err error) {
+	runtime.LogError(ContextX, "v1: "+msg+": "+err.Error())
+	if aerr, ok := err.(awserr.Error); ok {
+		runtime.LogError(ContextX, fmt.Sprintf("v1: AWS Error Code: %s, Message: %s", aerr.Code(), aerr.Message()))
+	}
+}
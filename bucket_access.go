@@ -0,0 +1,198 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// errCodeNoPublicAccessBlock 桶未配置公共访问阻止时返回的错误码
+const errCodeNoPublicAccessBlock = "NoSuchPublicAccessBlockConfiguration"
+
+// publicAccessBlockFromS3 将 SDK 的配置转换为 nodes.PublicAccessBlock
+func publicAccessBlockFromS3(config *s3.PublicAccessBlockConfiguration) nodes.PublicAccessBlock {
+	if config == nil {
+		return nodes.PublicAccessBlock{}
+	}
+	return nodes.PublicAccessBlock{
+		BlockPublicAcls:       aws.BoolValue(config.BlockPublicAcls),
+		IgnorePublicAcls:      aws.BoolValue(config.IgnorePublicAcls),
+		BlockPublicPolicy:     aws.BoolValue(config.BlockPublicPolicy),
+		RestrictPublicBuckets: aws.BoolValue(config.RestrictPublicBuckets),
+	}
+}
+
+// bucketACLFromS3 将 GetBucketAcl 的结果转换为 nodes.BucketACL
+func bucketACLFromS3(out *s3.GetBucketAclOutput) nodes.BucketACL {
+	acl := nodes.BucketACL{Grants: []nodes.ACLGrant{}}
+	if out.Owner != nil {
+		acl.OwnerID = aws.StringValue(out.Owner.ID)
+		acl.OwnerDisplayName = aws.StringValue(out.Owner.DisplayName)
+	}
+	for _, g := range out.Grants {
+		grant := nodes.ACLGrant{Permission: aws.StringValue(g.Permission)}
+		if g.Grantee != nil {
+			grant.GranteeType = aws.StringValue(g.Grantee.Type)
+			grant.ID = aws.StringValue(g.Grantee.ID)
+			grant.DisplayName = aws.StringValue(g.Grantee.DisplayName)
+			grant.URI = aws.StringValue(g.Grantee.URI)
+			grant.Email = aws.StringValue(g.Grantee.EmailAddress)
+		}
+		acl.Grants = append(acl.Grants, grant)
+	}
+	return acl
+}
+
+// GetPublicAccessBlock 返回桶的公共访问阻止设置，未配置时四个开关均为 false
+func (a *S3Manager) GetPublicAccessBlock(nodeID, bucketName string) (nodes.PublicAccessBlock, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetPublicAccessBlock: "+err.Error())
+		return nodes.PublicAccessBlock{}, err
+	}
+	out, err := s3Manager.client.GetPublicAccessBlockWithContext(context.Background(),
+		&s3.GetPublicAccessBlockInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == errCodeNoPublicAccessBlock {
+			return nodes.PublicAccessBlock{}, nil
+		}
+		logAWSError("Failed to get public access block", err)
+		return nodes.PublicAccessBlock{}, fmt.Errorf("v1: failed to get public access block for %s: %v", bucketName, err)
+	}
+	return publicAccessBlockFromS3(out.PublicAccessBlockConfiguration), nil
+}
+
+// PutPublicAccessBlock 设置桶的公共访问阻止
+func (a *S3Manager) PutPublicAccessBlock(nodeID, bucketName string, block nodes.PublicAccessBlock) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutPublicAccessBlock: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutPublicAccessBlockWithContext(context.Background(), &s3.PutPublicAccessBlockInput{
+		Bucket: aws.String(bucketName),
+		PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(block.BlockPublicAcls),
+			IgnorePublicAcls:      aws.Bool(block.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.Bool(block.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.Bool(block.RestrictPublicBuckets),
+		},
+	})
+	if err != nil {
+		logAWSError("Failed to put public access block", err)
+		return fmt.Errorf("v1: failed to put public access block for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Public access block for %s set to %+v", bucketName, block))
+	return nil
+}
+
+// DeletePublicAccessBlock 删除桶的公共访问阻止配置
+func (a *S3Manager) DeletePublicAccessBlock(nodeID, bucketName string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeletePublicAccessBlock: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeletePublicAccessBlockWithContext(context.Background(),
+		&s3.DeletePublicAccessBlockInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to delete public access block", err)
+		return fmt.Errorf("v1: failed to delete public access block for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Public access block removed for "+bucketName)
+	return nil
+}
+
+// GetBucketACL 返回桶的所有者和 ACL 授权
+func (a *S3Manager) GetBucketACL(nodeID, bucketName string) (nodes.BucketACL, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketACL: "+err.Error())
+		return nodes.BucketACL{}, err
+	}
+	out, err := s3Manager.client.GetBucketAclWithContext(context.Background(),
+		&s3.GetBucketAclInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to get bucket ACL", err)
+		return nodes.BucketACL{}, fmt.Errorf("v1: failed to get ACL for %s: %v", bucketName, err)
+	}
+	return bucketACLFromS3(out), nil
+}
+
+// SetBucketCannedACL 为桶设置预设 ACL(private / public-read / public-read-write / authenticated-read)
+func (a *S3Manager) SetBucketCannedACL(nodeID, bucketName, acl string) error {
+	switch acl {
+	case nodes.ACLPrivate, nodes.ACLPublicRead, nodes.ACLPublicReadWrite, nodes.ACLAuthenticatedRead:
+	default:
+		return fmt.Errorf("unknown canned ACL %q", acl)
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for SetBucketCannedACL: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutBucketAclWithContext(context.Background(), &s3.PutBucketAclInput{
+		Bucket: aws.String(bucketName),
+		ACL:    aws.String(acl),
+	})
+	if err != nil {
+		logAWSError("Failed to set bucket ACL", err)
+		return fmt.Errorf("v1: failed to set ACL for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s ACL set to %s", bucketName, acl))
+	return nil
+}
+
+// PutBucketACL 用显式授权列表替换桶的 ACL，未指定所有者时沿用当前所有者
+func (a *S3Manager) PutBucketACL(nodeID, bucketName string, acl nodes.BucketACL) error {
+	for _, grant := range acl.Grants {
+		if err := grant.Validate(); err != nil {
+			return err
+		}
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketACL: "+err.Error())
+		return err
+	}
+	ctx := context.Background()
+
+	owner := &s3.Owner{ID: aws.String(acl.OwnerID)}
+	if acl.OwnerID == "" {
+		current, err := s3Manager.client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{Bucket: aws.String(bucketName)})
+		if err != nil {
+			logAWSError("Failed to get bucket owner", err)
+			return fmt.Errorf("v1: failed to get owner of %s: %v", bucketName, err)
+		}
+		owner = current.Owner
+	}
+
+	policy := &s3.AccessControlPolicy{Owner: owner}
+	for _, g := range acl.Grants {
+		grantee := &s3.Grantee{Type: aws.String(g.GranteeType)}
+		switch g.GranteeType {
+		case nodes.GranteeCanonicalUser:
+			grantee.ID = aws.String(g.ID)
+		case nodes.GranteeGroup:
+			grantee.URI = aws.String(g.URI)
+		case nodes.GranteeEmail:
+			grantee.EmailAddress = aws.String(g.Email)
+		}
+		policy.Grants = append(policy.Grants, &s3.Grant{Grantee: grantee, Permission: aws.String(g.Permission)})
+	}
+
+	_, err = s3Manager.client.PutBucketAclWithContext(ctx, &s3.PutBucketAclInput{
+		Bucket:              aws.String(bucketName),
+		AccessControlPolicy: policy,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket ACL", err)
+		return fmt.Errorf("v1: failed to put ACL for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s ACL replaced with %d grants", bucketName, len(acl.Grants)))
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// errCodeNoCORS 桶未配置 CORS 时返回的错误码
+const errCodeNoCORS = "NoSuchCORSConfiguration"
+
+// corsRulesFromS3 将 SDK 的 CORS 规则转换为 nodes.CORSRule
+func corsRulesFromS3(in []*s3.CORSRule) []nodes.CORSRule {
+	rules := make([]nodes.CORSRule, 0, len(in))
+	for _, r := range in {
+		rules = append(rules, nodes.CORSRule{
+			ID:             aws.StringValue(r.ID),
+			AllowedOrigins: aws.StringValueSlice(r.AllowedOrigins),
+			AllowedMethods: aws.StringValueSlice(r.AllowedMethods),
+			AllowedHeaders: aws.StringValueSlice(r.AllowedHeaders),
+			ExposeHeaders:  aws.StringValueSlice(r.ExposeHeaders),
+			MaxAgeSeconds:  aws.Int64Value(r.MaxAgeSeconds),
+		})
+	}
+	return rules
+}
+
+// GetBucketCORS 返回桶的 CORS 规则，未配置时返回空列表
+func (a *S3Manager) GetBucketCORS(nodeID, bucketName string) ([]nodes.CORSRule, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketCORS: "+err.Error())
+		return nil, err
+	}
+	return s3Manager.corsRules(context.Background(), bucketName)
+}
+
+// PutBucketCORS 用 rules 替换桶的全部 CORS 规则，rules 为空时删除 CORS 配置
+func (a *S3Manager) PutBucketCORS(nodeID, bucketName string, rules []nodes.CORSRule) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketCORS: "+err.Error())
+		return err
+	}
+	return s3Manager.putCORSRules(context.Background(), bucketName, rules)
+}
+
+// PutCORSRule 替换下标为 index 的规则，index 为 -1 时追加新规则
+func (a *S3Manager) PutCORSRule(nodeID, bucketName string, index int, rule nodes.CORSRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return a.editCORS(nodeID, bucketName, func(rules []nodes.CORSRule) ([]nodes.CORSRule, error) {
+		if index == -1 {
+			return append(rules, rule), nil
+		}
+		if index < 0 || index >= len(rules) {
+			return nil, fmt.Errorf("CORS rule index %d out of range", index)
+		}
+		rules[index] = rule
+		return rules, nil
+	})
+}
+
+// DeleteCORSRule 删除下标为 index 的规则
+func (a *S3Manager) DeleteCORSRule(nodeID, bucketName string, index int) error {
+	return a.editCORS(nodeID, bucketName, func(rules []nodes.CORSRule) ([]nodes.CORSRule, error) {
+		if index < 0 || index >= len(rules) {
+			return nil, fmt.Errorf("CORS rule index %d out of range", index)
+		}
+		return append(rules[:index], rules[index+1:]...), nil
+	})
+}
+
+// DeleteBucketCORS 删除桶的全部 CORS 配置
+func (a *S3Manager) DeleteBucketCORS(nodeID, bucketName string) error {
+	return a.PutBucketCORS(nodeID, bucketName, nil)
+}
+
+// EvaluateCORS 在本地检查 rules 是否允许给定的跨域请求，用于排查预检失败
+func (a *S3Manager) EvaluateCORS(rules []nodes.CORSRule, request nodes.CORSRequest) nodes.CORSEvaluation {
+	return nodes.EvaluateCORS(rules, request)
+}
+
+// editCORS 读取当前规则，交给 fn 修改后整体写回
+func (a *S3Manager) editCORS(nodeID, bucketName string, fn func([]nodes.CORSRule) ([]nodes.CORSRule, error)) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for CORS update: "+err.Error())
+		return err
+	}
+	ctx := context.Background()
+	rules, err := s3Manager.corsRules(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	if rules, err = fn(rules); err != nil {
+		return err
+	}
+	return s3Manager.putCORSRules(ctx, bucketName, rules)
+}
+
+// corsRules 读取并转换桶的 CORS 规则
+func (a *S3Manager) corsRules(ctx context.Context, bucketName string) ([]nodes.CORSRule, error) {
+	out, err := a.client.GetBucketCorsWithContext(ctx, &s3.GetBucketCorsInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == errCodeNoCORS {
+			return []nodes.CORSRule{}, nil
+		}
+		logAWSError("Failed to get bucket CORS", err)
+		return nil, fmt.Errorf("v1: failed to get CORS for %s: %v", bucketName, err)
+	}
+	return corsRulesFromS3(out.CORSRules), nil
+}
+
+// putCORSRules 写回全部规则，规则为空时删除 CORS 配置
+func (a *S3Manager) putCORSRules(ctx context.Context, bucketName string, rules []nodes.CORSRule) error {
+	if len(rules) == 0 {
+		_, err := a.client.DeleteBucketCorsWithContext(ctx, &s3.DeleteBucketCorsInput{Bucket: aws.String(bucketName)})
+		if err != nil {
+			logAWSError("Failed to delete bucket CORS", err)
+			return fmt.Errorf("v1: failed to delete CORS for %s: %v", bucketName, err)
+		}
+		runtime.LogDebug(ContextX, "v1: CORS configuration removed for "+bucketName)
+		return nil
+	}
+
+	config := &s3.CORSConfiguration{}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		r := &s3.CORSRule{
+			AllowedOrigins: aws.StringSlice(rule.AllowedOrigins),
+			AllowedMethods: aws.StringSlice(rule.AllowedMethods),
+			ID:             optionalString(rule.ID),
+		}
+		if len(rule.AllowedHeaders) > 0 {
+			r.AllowedHeaders = aws.StringSlice(rule.AllowedHeaders)
+		}
+		if len(rule.ExposeHeaders) > 0 {
+			r.ExposeHeaders = aws.StringSlice(rule.ExposeHeaders)
+		}
+		if rule.MaxAgeSeconds > 0 {
+			r.MaxAgeSeconds = aws.Int64(rule.MaxAgeSeconds)
+		}
+		config.CORSRules = append(config.CORSRules, r)
+	}
+	_, err := a.client.PutBucketCorsWithContext(ctx, &s3.PutBucketCorsInput{
+		Bucket:            aws.String(bucketName),
+		CORSConfiguration: config,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket CORS", err)
+		return fmt.Errorf("v1: failed to put CORS for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s now has %d CORS rules", bucketName, len(rules)))
+	return nil
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 获取桶信息时的并发数：配置探测只是少量请求，对象统计需要完整列出桶内对象，单独限流
+const (
+	bucketProbeWorkers = 8
+	bucketScanWorkers  = 4
+)
+
+// bucketProbe 一项桶配置探测。run 在配置不存在时也返回 nil，只有真正的错误才返回
+type bucketProbe struct {
+	name string
+	run  func(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error
+}
+
+// bucketProbes 按顺序对每个桶执行的配置探测
+var bucketProbes = []bucketProbe{
+	{"versioning", probeVersioning},
+	{"publicAccessBlock", probePublicAccessBlock},
+	{"acl", probeACL},
+	{"policy", probePolicy},
+	{"cors", probeCORS},
+	{"tags", probeTags},
+	{"objectLock", probeObjectLock},
+	{"replication", probeReplication},
+	{"encryption", probeEncryption},
+	{"lifecycle", probeLifecycle},
+	{"website", probeWebsite},
+}
+
+// errBucketScanSuperseded 同一节点开始了新的获取，旧的获取以此为取消原因
+var errBucketScanSuperseded = errors.New("bucket info scan superseded by a newer one")
+
+// scanHandle 一次正在进行的桶信息获取
+type scanHandle struct {
+	cancel context.CancelCauseFunc
+}
+
+// bucketScans 按节点 ID 记录正在进行的桶信息获取，用于取消
+var bucketScans = struct {
+	sync.Mutex
+	handles map[string]*scanHandle
+}{handles: make(map[string]*scanHandle)}
+
+// startBucketScan 为节点开始一次新的获取并取消该节点上一次未完成的获取。
+// 返回的 done 必须在获取结束后调用
+func startBucketScan(nodeID string) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancelCause(appContext())
+	handle := &scanHandle{cancel: cancel}
+
+	bucketScans.Lock()
+	if previous, ok := bucketScans.handles[nodeID]; ok {
+		previous.cancel(errBucketScanSuperseded)
+	}
+	bucketScans.handles[nodeID] = handle
+	bucketScans.Unlock()
+
+	return ctx, func() {
+		cancel(nil)
+		bucketScans.Lock()
+		if bucketScans.handles[nodeID] == handle {
+			delete(bucketScans.handles, nodeID)
+		}
+		bucketScans.Unlock()
+	}
+}
+
+// CancelNodeBucketInfo 取消节点上正在进行的桶信息获取，已获取的部分仍会返回
+func (a *S3Manager) CancelNodeBucketInfo(nodeID string) {
+	bucketScans.Lock()
+	defer bucketScans.Unlock()
+	if handle, ok := bucketScans.handles[nodeID]; ok {
+		handle.cancel(nil)
+		runtime.LogDebug(ContextX, "v1: Cancelling bucket info for node "+nodeID)
+	}
+}
+
+// collectBucketInfo 列出节点上的桶，并发执行配置探测和对象统计。
+// ctx 被取消时尽快返回已获取的部分，并将 Cancelled 置为 true。
+// stream 不为 nil 时，桶列出、每项探测完成和每页对象统计后都会立即发送事件
+func (a *S3Manager) collectBucketInfo(ctx context.Context, stream *bucketInfoStream) (nodes.NodeBucketInfo, error) {
+	nodeBucketInfo := nodes.NodeBucketInfo{
+		NodeName: a.node.NodeName,
+		EndPoint: a.node.EndPoint,
+		Buckets:  []nodes.BucketInfo{},
+	}
+
+	resp, err := a.client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nodeBucketInfo, err
+	}
+
+	for _, bucket := range resp.Buckets {
+		if bucket.Name == nil || bucket.CreationDate == nil {
+			runtime.LogDebug(ContextX, "v1: Skipping bucket with nil name or creation date")
+			continue
+		}
+		nodeBucketInfo.Buckets = append(nodeBucketInfo.Buckets, nodes.BucketInfo{
+			Name:         *bucket.Name,
+			CreationDate: *bucket.CreationDate,
+			Region:       a.node.Region, // Use the node's region
+		})
+	}
+	for _, info := range nodeBucketInfo.Buckets {
+		stream.bucket("", info, nil)
+	}
+
+	// 配置探测直接写入各自的 BucketInfo；对象统计先写入 sizes，全部结束后再合并，避免并发写同一结构
+	type bucketSize struct {
+		objects, bytes int64
+		complete       bool
+	}
+	sizes := make([]bucketSize, len(nodeBucketInfo.Buckets))
+	probeSem := make(chan struct{}, bucketProbeWorkers)
+	scanSem := make(chan struct{}, bucketScanWorkers)
+	var wg sync.WaitGroup
+
+	for i := range nodeBucketInfo.Buckets {
+		info := &nodeBucketInfo.Buckets[i]
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if !acquire(ctx, probeSem) {
+				return
+			}
+			defer func() { <-probeSem }()
+			a.probeBucket(ctx, info, stream)
+		}()
+		go func(i int, name string) {
+			defer wg.Done()
+			if !acquire(ctx, scanSem) {
+				return
+			}
+			defer func() { <-scanSem }()
+			objects, bytes, err := a.scanBucketSize(ctx, name, func(objects, bytes int64) {
+				stream.size(name, objects, bytes, false, false)
+			})
+			if err != nil && ctx.Err() == nil {
+				runtime.LogError(ContextX, fmt.Sprintf("v1: Failed getting objects for bucket %s: %s", name, err.Error()))
+			}
+			sizes[i] = bucketSize{objects, bytes, err == nil}
+			stream.size(name, objects, bytes, true, err != nil)
+		}(i, info.Name)
+	}
+	wg.Wait()
+
+	for i := range nodeBucketInfo.Buckets {
+		info := &nodeBucketInfo.Buckets[i]
+		info.TotalObjects, info.UsedSpace = sizes[i].objects, sizes[i].bytes
+		// 未开始或中途取消、失败的统计都不是最终结果
+		info.SizeIncomplete = !sizes[i].complete
+		runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket info: %s, Objects: %d, Size: %d bytes, incomplete: %t",
+			info.Name, info.TotalObjects, info.UsedSpace, info.SizeIncomplete))
+	}
+	nodeBucketInfo.Cancelled = ctx.Err() != nil
+	return nodeBucketInfo, nil
+}
+
+// appContext 返回应用的 context，程序退出时取消；启动前为 context.Background()
+func appContext() context.Context {
+	if ContextX == nil {
+		return context.Background()
+	}
+	return ContextX
+}
+
+// acquire 获取一个并发名额，ctx 已取消时返回 false
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// probeBucket 依次执行全部配置探测，单项失败只记录日志。
+// 每项探测结束后将当前的桶信息发送给 stream
+func (a *S3Manager) probeBucket(ctx context.Context, info *nodes.BucketInfo, stream *bucketInfoStream) {
+	for _, probe := range bucketProbes {
+		if ctx.Err() != nil {
+			return
+		}
+		err := probe.run(ctx, a, info)
+		if err != nil && ctx.Err() == nil {
+			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get %s for %s: %s", probe.name, info.Name, err.Error()))
+		}
+		stream.bucket(probe.name, *info, err)
+	}
+}
+
+// scanBucketSize 分页列出桶内对象，统计对象数和总大小。
+// onPage 在每页之后收到累计值，可为 nil
+func (a *S3Manager) scanBucketSize(ctx context.Context, bucketName string,
+	onPage func(objects, bytes int64)) (objects, bytes int64, err error) {
+	err = a.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			objects += int64(len(page.Contents))
+			for _, obj := range page.Contents {
+				bytes += aws.Int64Value(obj.Size)
+			}
+			if onPage != nil && !lastPage {
+				onPage(objects, bytes)
+			}
+			return true
+		})
+	return objects, bytes, err
+}
+
+func probeVersioning(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return err
+	}
+	versioning := versioningConfigFromOutput(out)
+	info.VersioningStatus = versioning.Status
+	info.MFADelete = versioning.MFADelete
+	info.VersioningEnabled = versioning.Status == nodes.VersioningEnabled
+	return nil
+}
+
+func probePublicAccessBlock(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetPublicAccessBlockWithContext(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, errCodeNoPublicAccessBlock)
+	}
+	info.PublicAccessBlock = publicAccessBlockFromS3(out.PublicAccessBlockConfiguration)
+	info.PublicAccessBlocked = info.PublicAccessBlock.AllBlocked()
+	return nil
+}
+
+func probeACL(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return err
+	}
+	info.ACLGrants = bucketACLFromS3(out).Grants
+	return nil
+}
+
+func probePolicy(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	_, err := m.client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, "NoSuchBucketPolicy")
+	}
+	info.HasPolicy = true
+	return nil
+}
+
+func probeCORS(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketCorsWithContext(ctx, &s3.GetBucketCorsInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, errCodeNoCORS)
+	}
+	info.CORSRules = corsRulesFromS3(out.CORSRules)
+	return nil
+}
+
+func probeTags(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, "NoSuchTagSet")
+	}
+	info.Tags = tagsFromS3(out.TagSet)
+	return nil
+}
+
+func probeObjectLock(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, errCodeNoObjectLock)
+	}
+	info.ObjectLock = objectLockConfigFromS3(out.ObjectLockConfiguration)
+	return nil
+}
+
+func probeReplication(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketReplicationWithContext(ctx, &s3.GetBucketReplicationInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, errCodeNoReplication)
+	}
+	info.Replication = replicationConfigFromS3(out.ReplicationConfiguration)
+	info.ReplicationEnabled = info.Replication.Enabled()
+	return nil
+}
+
+func probeEncryption(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketEncryptionWithContext(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		// 未配置默认加密不算错误，其他错误单独记录，与"未启用"区分
+		if err = ignoreCode(err, errCodeNoEncryption); err != nil && ctx.Err() == nil {
+			info.EncryptionError = err.Error()
+		}
+		return err
+	}
+	info.EncryptionRules = encryptionRulesFromS3(out.ServerSideEncryptionConfiguration)
+	if len(info.EncryptionRules) > 0 {
+		info.EncryptionEnabled = true
+		// Keep the first rule's algorithm for the summary view
+		info.EncryptionType = info.EncryptionRules[0].Algorithm
+	}
+	return nil
+}
+
+func probeLifecycle(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketLifecycleConfigurationWithContext(ctx,
+		&s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, "NoSuchLifecycleConfiguration")
+	}
+	info.LifecycleRules = lifecycleRulesFromS3(out.Rules)
+	info.LifecycleRulesCount = len(info.LifecycleRules)
+	info.HasLifecycleRules = info.LifecycleRulesCount > 0
+	return nil
+}
+
+func probeWebsite(ctx context.Context, m *S3Manager, info *nodes.BucketInfo) error {
+	out, err := m.client.GetBucketWebsiteWithContext(ctx, &s3.GetBucketWebsiteInput{Bucket: aws.String(info.Name)})
+	if err != nil {
+		return ignoreCode(err, "NoSuchWebsiteConfiguration")
+	}
+	info.WebsiteEnabled = true
+	info.Website = websiteConfigFromS3(out)
+	return nil
+}
+
+// ignoreCode 错误码为 code(表示配置不存在)时返回 nil
+func ignoreCode(err error, code string) error {
+	if awsErrorCode(err) == code {
+		return nil
+	}
+	return err
+}
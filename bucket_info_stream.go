@@ -0,0 +1,114 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 流式获取桶信息时发送给前端的事件
+const (
+	bucketInfoBucketEvent = "bucketinfo:bucket" // 桶列出或某项配置探测完成
+	bucketInfoSizeEvent   = "bucketinfo:size"   // 对象统计进度
+	bucketInfoDoneEvent   = "bucketinfo:done"   // 获取结束
+)
+
+// BucketInfoUpdate 单个桶的信息更新
+type BucketInfoUpdate struct {
+	NodeID string           `json:"nodeId"`          // 节点 ID
+	Probe  string           `json:"probe"`           // 刚完成的配置探测，桶刚列出时为空
+	Error  string           `json:"error,omitempty"` // 该项探测失败的原因
+	Bucket nodes.BucketInfo `json:"bucket"`          // 当前已知的桶信息，对象统计以 bucketinfo:size 为准
+}
+
+// BucketSizeProgress 桶对象统计的累计进度
+type BucketSizeProgress struct {
+	NodeID     string `json:"nodeId"`     // 节点 ID
+	Bucket     string `json:"bucket"`     // 桶名称
+	Objects    int64  `json:"objects"`    // 已统计的对象数量
+	Bytes      int64  `json:"bytes"`      // 已统计的总大小(字节)
+	Done       bool   `json:"done"`       // 统计是否已结束
+	Incomplete bool   `json:"incomplete"` // 统计因出错提前结束，数量只是部分结果
+}
+
+// BucketInfoDone 流式获取结束，Info 为完整结果(取消时为已获取的部分)
+type BucketInfoDone struct {
+	NodeID string               `json:"nodeId"`          // 节点 ID
+	Info   nodes.NodeBucketInfo `json:"info"`            // 最终的节点桶信息
+	Error  string               `json:"error,omitempty"` // 列出桶失败的原因
+}
+
+// bucketInfoStream 将获取过程中的中间结果作为事件发送给前端。
+// 方法在 nil 接收者上不做任何事，非流式获取直接传 nil
+type bucketInfoStream struct {
+	ctx    context.Context
+	nodeID string
+}
+
+// emit 获取已取消时不再发送中间结果
+func (s *bucketInfoStream) emit(name string, data interface{}) {
+	if s == nil || s.ctx.Err() != nil {
+		return
+	}
+	runtime.EventsEmit(ContextX, name, data)
+}
+
+func (s *bucketInfoStream) bucket(probe string, info nodes.BucketInfo, err error) {
+	update := BucketInfoUpdate{NodeID: s.id(), Probe: probe, Bucket: info}
+	if err != nil {
+		update.Error = err.Error()
+	}
+	s.emit(bucketInfoBucketEvent, update)
+}
+
+func (s *bucketInfoStream) size(bucketName string, objects, bytes int64, done, incomplete bool) {
+	s.emit(bucketInfoSizeEvent, BucketSizeProgress{
+		NodeID:     s.id(),
+		Bucket:     bucketName,
+		Objects:    objects,
+		Bytes:      bytes,
+		Done:       done,
+		Incomplete: incomplete,
+	})
+}
+
+func (s *bucketInfoStream) id() string {
+	if s == nil {
+		return ""
+	}
+	return s.nodeID
+}
+
+// StreamNodeBucketInfo 与 GetNodeBucketInfo 相同，但立即返回，结果通过事件逐步发送:
+// 每个桶列出后和每项配置探测完成后发送 bucketinfo:bucket，对象统计每页发送 bucketinfo:size，
+// 结束时发送 bucketinfo:done。CancelNodeBucketInfo 可提前结束；
+// 被同一节点上新的获取取代时不再发送 bucketinfo:done
+func (a *S3Manager) StreamNodeBucketInfo(nodeID string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for StreamNodeBucketInfo: "+err.Error())
+		return err
+	}
+
+	ctx, done := startBucketScan(nodeID)
+	go func() {
+		defer done()
+		runtime.LogDebug(ContextX, "v1: Streaming node bucket info for node "+nodeID)
+
+		info, err := s3Manager.collectBucketInfo(ctx, &bucketInfoStream{ctx: ctx, nodeID: nodeID})
+		result := BucketInfoDone{NodeID: nodeID, Info: info}
+		if err != nil {
+			runtime.LogError(ContextX, "v1: Failed to list buckets: "+err.Error())
+			result.Error = fmt.Sprintf("v1: failed to list buckets: %v", err)
+		}
+		if errors.Is(context.Cause(ctx), errBucketScanSuperseded) {
+			runtime.LogDebug(ContextX, "v1: Bucket info stream for node "+nodeID+" was superseded")
+			return
+		}
+		runtime.EventsEmit(ContextX, bucketInfoDoneEvent, result)
+	}()
+	return nil
+}
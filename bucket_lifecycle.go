@@ -0,0 +1,280 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetBucketLifecycle 返回桶的全部生命周期规则，未配置时返回空列表
+func (a *S3Manager) GetBucketLifecycle(nodeID, bucketName string) ([]nodes.LifecycleRule, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketLifecycle: "+err.Error())
+		return nil, err
+	}
+	return s3Manager.lifecycleRules(context.Background(), bucketName)
+}
+
+// PutLifecycleRule 新增规则，或替换 ID 相同的已有规则
+func (a *S3Manager) PutLifecycleRule(nodeID, bucketName string, rule nodes.LifecycleRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return a.editLifecycle(nodeID, bucketName, func(rules []nodes.LifecycleRule) ([]nodes.LifecycleRule, error) {
+		for i := range rules {
+			if rules[i].ID == rule.ID {
+				rules[i] = rule
+				return rules, nil
+			}
+		}
+		return append(rules, rule), nil
+	})
+}
+
+// DeleteLifecycleRule 删除指定 ID 的规则，删除最后一条规则时移除整个生命周期配置
+func (a *S3Manager) DeleteLifecycleRule(nodeID, bucketName, ruleID string) error {
+	return a.editLifecycle(nodeID, bucketName, func(rules []nodes.LifecycleRule) ([]nodes.LifecycleRule, error) {
+		for i := range rules {
+			if rules[i].ID == ruleID {
+				return append(rules[:i], rules[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("lifecycle rule %q not found", ruleID)
+	})
+}
+
+// editLifecycle 读取当前规则，交给 fn 修改后整体写回
+func (a *S3Manager) editLifecycle(nodeID, bucketName string, fn func([]nodes.LifecycleRule) ([]nodes.LifecycleRule, error)) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for lifecycle update: "+err.Error())
+		return err
+	}
+	ctx := context.Background()
+	rules, err := s3Manager.lifecycleRules(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	if rules, err = fn(rules); err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		_, err = s3Manager.client.DeleteBucketLifecycleWithContext(ctx,
+			&s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucketName)})
+		if err != nil {
+			logAWSError("Failed to delete bucket lifecycle", err)
+			return fmt.Errorf("v1: failed to delete lifecycle for %s: %v", bucketName, err)
+		}
+		runtime.LogDebug(ContextX, "v1: Lifecycle configuration removed for "+bucketName)
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	config := &s3.BucketLifecycleConfiguration{}
+	for _, rule := range rules {
+		if seen[rule.ID] {
+			return fmt.Errorf("duplicate lifecycle rule ID %q", rule.ID)
+		}
+		seen[rule.ID] = true
+		config.Rules = append(config.Rules, lifecycleRuleToS3(rule))
+	}
+	_, err = s3Manager.client.PutBucketLifecycleConfigurationWithContext(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucketName),
+		LifecycleConfiguration: config,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket lifecycle", err)
+		return fmt.Errorf("v1: failed to put lifecycle for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s now has %d lifecycle rules", bucketName, len(rules)))
+	return nil
+}
+
+// lifecycleRules 读取并转换桶的生命周期规则
+func (a *S3Manager) lifecycleRules(ctx context.Context, bucketName string) ([]nodes.LifecycleRule, error) {
+	out, err := a.client.GetBucketLifecycleConfigurationWithContext(ctx,
+		&s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == "NoSuchLifecycleConfiguration" {
+			return []nodes.LifecycleRule{}, nil
+		}
+		logAWSError("Failed to get bucket lifecycle", err)
+		return nil, fmt.Errorf("v1: failed to get lifecycle for %s: %v", bucketName, err)
+	}
+	return lifecycleRulesFromS3(out.Rules), nil
+}
+
+// lifecycleRulesFromS3 将 SDK 的规则转换为 nodes.LifecycleRule
+func lifecycleRulesFromS3(in []*s3.LifecycleRule) []nodes.LifecycleRule {
+	rules := make([]nodes.LifecycleRule, 0, len(in))
+	for _, r := range in {
+		rule := nodes.LifecycleRule{
+			ID:     aws.StringValue(r.ID),
+			Status: aws.StringValue(r.Status),
+			Filter: nodes.LifecycleFilter{Prefix: aws.StringValue(r.Prefix)}, // 旧版规则直接使用 Prefix
+		}
+		if f := r.Filter; f != nil {
+			if f.And != nil {
+				rule.Filter = nodes.LifecycleFilter{
+					Prefix:                aws.StringValue(f.And.Prefix),
+					Tags:                  tagsFromS3(f.And.Tags),
+					ObjectSizeGreaterThan: aws.Int64Value(f.And.ObjectSizeGreaterThan),
+					ObjectSizeLessThan:    aws.Int64Value(f.And.ObjectSizeLessThan),
+				}
+			} else {
+				rule.Filter = nodes.LifecycleFilter{
+					Prefix:                aws.StringValue(f.Prefix),
+					ObjectSizeGreaterThan: aws.Int64Value(f.ObjectSizeGreaterThan),
+					ObjectSizeLessThan:    aws.Int64Value(f.ObjectSizeLessThan),
+				}
+				if f.Tag != nil {
+					rule.Filter.Tags = tagsFromS3([]*s3.Tag{f.Tag})
+				}
+			}
+		}
+		if e := r.Expiration; e != nil {
+			rule.Expiration = &nodes.LifecycleExpiration{
+				Days:                      aws.Int64Value(e.Days),
+				Date:                      lifecycleDate(e.Date),
+				ExpiredObjectDeleteMarker: aws.BoolValue(e.ExpiredObjectDeleteMarker),
+			}
+		}
+		if n := r.NoncurrentVersionExpiration; n != nil {
+			rule.NoncurrentExpiration = &nodes.LifecycleNoncurrentExpiration{
+				NoncurrentDays:          aws.Int64Value(n.NoncurrentDays),
+				NewerNoncurrentVersions: aws.Int64Value(n.NewerNoncurrentVersions),
+			}
+		}
+		for _, t := range r.Transitions {
+			rule.Transitions = append(rule.Transitions, nodes.LifecycleTransition{
+				Days:         aws.Int64Value(t.Days),
+				Date:         lifecycleDate(t.Date),
+				StorageClass: aws.StringValue(t.StorageClass),
+			})
+		}
+		for _, t := range r.NoncurrentVersionTransitions {
+			rule.NoncurrentTransitions = append(rule.NoncurrentTransitions, nodes.LifecycleNoncurrentTransition{
+				NoncurrentDays:          aws.Int64Value(t.NoncurrentDays),
+				NewerNoncurrentVersions: aws.Int64Value(t.NewerNoncurrentVersions),
+				StorageClass:            aws.StringValue(t.StorageClass),
+			})
+		}
+		if r.AbortIncompleteMultipartUpload != nil {
+			rule.AbortIncompleteMultipartDays = aws.Int64Value(r.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// lifecycleRuleToS3 将 nodes.LifecycleRule 转换为 SDK 的规则，规则需已通过 Validate
+func lifecycleRuleToS3(rule nodes.LifecycleRule) *s3.LifecycleRule {
+	out := &s3.LifecycleRule{
+		ID:     aws.String(rule.ID),
+		Status: aws.String(rule.Status),
+		Filter: lifecycleFilterToS3(rule.Filter),
+	}
+	if e := rule.Expiration; e != nil {
+		out.Expiration = &s3.LifecycleExpiration{}
+		switch {
+		case e.Days > 0:
+			out.Expiration.Days = aws.Int64(e.Days)
+		case e.Date != "":
+			out.Expiration.Date = parseLifecycleDate(e.Date)
+		default:
+			out.Expiration.ExpiredObjectDeleteMarker = aws.Bool(e.ExpiredObjectDeleteMarker)
+		}
+	}
+	if n := rule.NoncurrentExpiration; n != nil {
+		out.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{NoncurrentDays: aws.Int64(n.NoncurrentDays)}
+		if n.NewerNoncurrentVersions > 0 {
+			out.NoncurrentVersionExpiration.NewerNoncurrentVersions = aws.Int64(n.NewerNoncurrentVersions)
+		}
+	}
+	for _, t := range rule.Transitions {
+		transition := &s3.Transition{StorageClass: aws.String(t.StorageClass)}
+		if t.Date != "" {
+			transition.Date = parseLifecycleDate(t.Date)
+		} else {
+			transition.Days = aws.Int64(t.Days)
+		}
+		out.Transitions = append(out.Transitions, transition)
+	}
+	for _, t := range rule.NoncurrentTransitions {
+		transition := &s3.NoncurrentVersionTransition{
+			NoncurrentDays: aws.Int64(t.NoncurrentDays),
+			StorageClass:   aws.String(t.StorageClass),
+		}
+		if t.NewerNoncurrentVersions > 0 {
+			transition.NewerNoncurrentVersions = aws.Int64(t.NewerNoncurrentVersions)
+		}
+		out.NoncurrentVersionTransitions = append(out.NoncurrentVersionTransitions, transition)
+	}
+	if rule.AbortIncompleteMultipartDays > 0 {
+		out.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: aws.Int64(rule.AbortIncompleteMultipartDays),
+		}
+	}
+	return out
+}
+
+// lifecycleFilterToS3 单一条件直接写入 Filter，多个条件时使用 And
+func lifecycleFilterToS3(f nodes.LifecycleFilter) *s3.LifecycleRuleFilter {
+	conditions := len(f.Tags)
+	if f.Prefix != "" {
+		conditions++
+	}
+	if f.ObjectSizeGreaterThan > 0 {
+		conditions++
+	}
+	if f.ObjectSizeLessThan > 0 {
+		conditions++
+	}
+
+	if conditions > 1 {
+		and := &s3.LifecycleRuleAndOperator{Tags: tagsToS3(f.Tags)}
+		if f.Prefix != "" {
+			and.Prefix = aws.String(f.Prefix)
+		}
+		if f.ObjectSizeGreaterThan > 0 {
+			and.ObjectSizeGreaterThan = aws.Int64(f.ObjectSizeGreaterThan)
+		}
+		if f.ObjectSizeLessThan > 0 {
+			and.ObjectSizeLessThan = aws.Int64(f.ObjectSizeLessThan)
+		}
+		return &s3.LifecycleRuleFilter{And: and}
+	}
+
+	filter := &s3.LifecycleRuleFilter{}
+	switch {
+	case len(f.Tags) == 1:
+		filter.Tag = tagsToS3(f.Tags)[0]
+	case f.ObjectSizeGreaterThan > 0:
+		filter.ObjectSizeGreaterThan = aws.Int64(f.ObjectSizeGreaterThan)
+	case f.ObjectSizeLessThan > 0:
+		filter.ObjectSizeLessThan = aws.Int64(f.ObjectSizeLessThan)
+	default:
+		filter.Prefix = aws.String(f.Prefix)
+	}
+	return filter
+}
+
+func lifecycleDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(nodes.LifecycleDateLayout)
+}
+
+// parseLifecycleDate 生命周期日期必须是 UTC 零点
+func parseLifecycleDate(date string) *time.Time {
+	t, _ := time.Parse(nodes.LifecycleDateLayout, date)
+	return aws.Time(t)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"SRSC-Client/accesslog"
+	nodes "SRSC-Client/type"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 分析访问日志时的默认日志对象上限和下载并发数
+const (
+	defaultMaxLogObjects = 1000
+	logDownloadWorkers   = 8
+)
+
+// GetBucketLogging 返回桶的访问日志配置
+func (a *S3Manager) GetBucketLogging(nodeID, bucketName string) (nodes.LoggingConfig, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketLogging: "+err.Error())
+		return nodes.LoggingConfig{}, err
+	}
+	out, err := s3Manager.client.GetBucketLoggingWithContext(context.Background(),
+		&s3.GetBucketLoggingInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to get bucket logging", err)
+		return nodes.LoggingConfig{}, fmt.Errorf("v1: failed to get logging for %s: %v", bucketName, err)
+	}
+	return loggingConfigFromS3(out.LoggingEnabled), nil
+}
+
+// loggingConfigFromS3 将 SDK 的日志配置转换为 nodes.LoggingConfig
+func loggingConfigFromS3(in *s3.LoggingEnabled) nodes.LoggingConfig {
+	if in == nil {
+		return nodes.LoggingConfig{}
+	}
+	return nodes.LoggingConfig{
+		Enabled:      true,
+		TargetBucket: aws.StringValue(in.TargetBucket),
+		TargetPrefix: aws.StringValue(in.TargetPrefix),
+	}
+}
+
+// EnableBucketLogging 将桶的访问日志写入 targetBucket 的 targetPrefix 下。
+// 目标桶需要与源桶在同一区域，并允许日志服务写入
+func (a *S3Manager) EnableBucketLogging(nodeID, bucketName, targetBucket, targetPrefix string) error {
+	if targetBucket == "" {
+		return fmt.Errorf("a target bucket is required to enable access logging")
+	}
+	if targetBucket == bucketName && targetPrefix == "" {
+		return fmt.Errorf("logging a bucket into itself needs a target prefix")
+	}
+	return a.putBucketLogging(nodeID, bucketName, &s3.BucketLoggingStatus{
+		LoggingEnabled: &s3.LoggingEnabled{
+			TargetBucket: aws.String(targetBucket),
+			TargetPrefix: aws.String(targetPrefix),
+		},
+	})
+}
+
+// DisableBucketLogging 关闭桶的访问日志
+func (a *S3Manager) DisableBucketLogging(nodeID, bucketName string) error {
+	return a.putBucketLogging(nodeID, bucketName, &s3.BucketLoggingStatus{})
+}
+
+func (a *S3Manager) putBucketLogging(nodeID, bucketName string, status *s3.BucketLoggingStatus) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketLogging: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutBucketLoggingWithContext(context.Background(), &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucketName),
+		BucketLoggingStatus: status,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket logging", err)
+		return fmt.Errorf("v1: failed to set logging for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Access logging for %s set to %+v", bucketName, loggingConfigFromS3(status.LoggingEnabled)))
+	return nil
+}
+
+// AnalyzeAccessLogs 下载 logBucket 中 prefix 下的访问日志对象并汇总。
+// 最多读取最新的 maxObjects 个日志对象，maxObjects <= 0 时使用默认值 1000
+func (a *S3Manager) AnalyzeAccessLogs(nodeID, logBucket, prefix string, maxObjects int) (accesslog.Report, error) {
+	if maxObjects <= 0 {
+		maxObjects = defaultMaxLogObjects
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for AnalyzeAccessLogs: "+err.Error())
+		return accesslog.Report{}, err
+	}
+	ctx := appContext()
+
+	// 日志对象键以时间开头，按字典序列出后保留最后 maxObjects 个即为最新的日志
+	var keys []string
+	err = s3Manager.client.ListObjectsV2PagesWithContext(ctx,
+		&s3.ListObjectsV2Input{Bucket: aws.String(logBucket), Prefix: aws.String(prefix)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				keys = append(keys, aws.StringValue(obj.Key))
+			}
+			if len(keys) > 2*maxObjects {
+				keys = append(keys[:0], keys[len(keys)-maxObjects:]...)
+			}
+			return true
+		})
+	if err != nil {
+		logAWSError("Failed to list access log objects", err)
+		return accesslog.Report{}, fmt.Errorf("v1: failed to list logs in %s: %v", logBucket, err)
+	}
+	if len(keys) > maxObjects {
+		keys = keys[len(keys)-maxObjects:]
+	}
+
+	aggregator := accesslog.NewAggregator()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := func(key, action string, err error) {
+		runtime.LogError(ContextX, fmt.Sprintf("v1: Failed to %s log %s: %s", action, key, err.Error()))
+		mu.Lock()
+		aggregator.AddFailed()
+		mu.Unlock()
+	}
+	jobs := make(chan string)
+	for i := 0; i < logDownloadWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				out, err := s3Manager.client.GetObjectWithContext(ctx,
+					&s3.GetObjectInput{Bucket: aws.String(logBucket), Key: aws.String(key)})
+				if err != nil {
+					failed(key, "download", err)
+					continue
+				}
+				// 先在锁外读完日志，汇总时只短暂持锁
+				data, err := io.ReadAll(out.Body)
+				out.Body.Close()
+				if err != nil {
+					failed(key, "read", err)
+					continue
+				}
+				mu.Lock()
+				malformed, err := aggregator.AddLog(bytes.NewReader(data))
+				mu.Unlock()
+				if err != nil {
+					// 出错前的行已经汇总，该日志只统计了一部分
+					failed(key, "parse", err)
+				}
+				if malformed > 0 {
+					runtime.LogDebug(ContextX, fmt.Sprintf("v1: Log %s has %d malformed lines", key, malformed))
+				}
+			}
+		}()
+	}
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		runtime.LogDebug(ContextX, "v1: Access log analysis cancelled")
+		return accesslog.Report{}, fmt.Errorf("v1: access log analysis for %s cancelled: %v", logBucket, err)
+	}
+
+	report := aggregator.Report()
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Analyzed %d log objects from %s: %d requests, %d malformed lines, %d failed logs",
+		report.LogObjects, logBucket, report.Requests, report.Malformed, report.FailedLogs))
+	return report, nil
+}
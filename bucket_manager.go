@@ -0,0 +1,215 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// deleteProgressEvent 清空桶时发送给前端的进度事件
+const deleteProgressEvent = "bucket:delete-progress"
+
+// DeleteProgress 清空桶的进度
+type DeleteProgress struct {
+	Bucket  string `json:"bucket"`  // 桶名称
+	Deleted int64  `json:"deleted"` // 已删除的对象版本和删除标记数量
+	Failed  int64  `json:"failed"`  // 删除失败的数量(如受对象锁保护)
+	Done    bool   `json:"done"`    // 是否已完成
+}
+
+// CreateBucket 在节点上创建桶，可指定位置约束、创建时启用对象锁和版本控制
+func (a *S3Manager) CreateBucket(nodeID string, opts nodes.CreateBucketOptions) error {
+	if err := nodes.ValidateBucketName(opts.Name); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for CreateBucket: "+err.Error())
+		return err
+	}
+
+	region := opts.Region
+	if region == "" {
+		region = s3Manager.node.Region
+	}
+	input := &s3.CreateBucketInput{Bucket: aws.String(opts.Name)}
+	// us-east-1 以及 R2 等使用 "auto" 的服务不接受位置约束
+	if region != "" && region != "us-east-1" && region != "auto" {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(region),
+		}
+	}
+	if opts.ObjectLock {
+		input.ObjectLockEnabledForBucket = aws.Bool(true)
+	}
+
+	ctx := context.Background()
+	if _, err := s3Manager.client.CreateBucketWithContext(ctx, input); err != nil {
+		logAWSError("Failed to create bucket", err)
+		return fmt.Errorf("v1: failed to create bucket %s: %v", opts.Name, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s created in %s", opts.Name, region))
+
+	// 启用对象锁时 S3 会自动启用版本控制
+	if opts.Versioning && !opts.ObjectLock {
+		_, err := s3Manager.client.PutBucketVersioningWithContext(ctx, &s3.PutBucketVersioningInput{
+			Bucket: aws.String(opts.Name),
+			VersioningConfiguration: &s3.VersioningConfiguration{
+				Status: aws.String(s3.BucketVersioningStatusEnabled),
+			},
+		})
+		if err != nil {
+			logAWSError("Failed to enable versioning on new bucket", err)
+			return fmt.Errorf("v1: bucket %s was created but enabling versioning failed: %v", opts.Name, err)
+		}
+	}
+	return nil
+}
+
+// DeleteBucket 删除桶。桶非空时拒绝删除，除非 emptyFirst 为 true，
+// 此时会先删除所有对象版本、删除标记和未完成的分片上传，并通过 bucket:delete-progress 事件报告进度
+func (a *S3Manager) DeleteBucket(nodeID, bucketName string, emptyFirst bool) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteBucket: "+err.Error())
+		return err
+	}
+	ctx := context.Background()
+
+	empty, err := s3Manager.bucketIsEmpty(ctx, bucketName)
+	if err != nil {
+		logAWSError("Failed to check whether bucket is empty", err)
+		return fmt.Errorf("v1: unable to check bucket %s: %v", bucketName, err)
+	}
+	if !empty {
+		if !emptyFirst {
+			return fmt.Errorf("bucket %s is not empty; confirm emptying it first to delete it", bucketName)
+		}
+		if err := s3Manager.emptyBucket(ctx, bucketName); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s3Manager.client.DeleteBucketWithContext(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucketName)}); err != nil {
+		logAWSError("Failed to delete bucket", err)
+		return fmt.Errorf("v1: failed to delete bucket %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Bucket deleted: "+bucketName)
+	return nil
+}
+
+// bucketIsEmpty 检查桶中是否没有任何对象版本或删除标记
+func (a *S3Manager) bucketIsEmpty(ctx context.Context, bucketName string) (bool, error) {
+	out, err := a.client.ListObjectVersionsWithContext(ctx, &s3.ListObjectVersionsInput{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int64(1),
+	})
+	if err == nil {
+		return len(out.Versions) == 0 && len(out.DeleteMarkers) == 0, nil
+	}
+	if awsErrorCode(err) != "NotImplemented" {
+		return false, err
+	}
+	// 不支持版本列表的服务(如部分 S3 兼容存储)退回到普通列表
+	listOut, err := a.client.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int64(1),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(listOut.Contents) == 0, nil
+}
+
+// emptyBucket 删除桶中所有对象版本、删除标记和未完成的分片上传
+func (a *S3Manager) emptyBucket(ctx context.Context, bucketName string) error {
+	progress := DeleteProgress{Bucket: bucketName}
+	report := func() {
+		runtime.EventsEmit(ContextX, deleteProgressEvent, progress)
+	}
+
+	deleteBatch := func(ids []*s3.ObjectIdentifier) error {
+		if len(ids) == 0 {
+			return nil
+		}
+		out, err := a.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &s3.Delete{Objects: ids, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range out.Errors {
+			runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to delete %s (%s): %s",
+				aws.StringValue(e.Key), aws.StringValue(e.VersionId), aws.StringValue(e.Message)))
+		}
+		progress.Failed += int64(len(out.Errors))
+		progress.Deleted += int64(len(ids) - len(out.Errors))
+		report()
+		return nil
+	}
+
+	var batchErr error
+	err := a.client.ListObjectVersionsPagesWithContext(ctx, &s3.ListObjectVersionsInput{Bucket: aws.String(bucketName)},
+		func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+			ids := make([]*s3.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+			for _, v := range page.Versions {
+				ids = append(ids, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+			}
+			for _, d := range page.DeleteMarkers {
+				ids = append(ids, &s3.ObjectIdentifier{Key: d.Key, VersionId: d.VersionId})
+			}
+			batchErr = deleteBatch(ids)
+			return batchErr == nil
+		})
+	if err != nil && awsErrorCode(err) == "NotImplemented" {
+		err = a.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)},
+			func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+				ids := make([]*s3.ObjectIdentifier, 0, len(page.Contents))
+				for _, obj := range page.Contents {
+					ids = append(ids, &s3.ObjectIdentifier{Key: obj.Key})
+				}
+				batchErr = deleteBatch(ids)
+				return batchErr == nil
+			})
+	}
+	if err == nil {
+		err = batchErr
+	}
+	if err != nil {
+		logAWSError("Failed to empty bucket", err)
+		return fmt.Errorf("v1: failed to empty bucket %s: %v", bucketName, err)
+	}
+
+	// 未完成的分片上传也会阻止删除桶
+	err = a.client.ListMultipartUploadsPagesWithContext(ctx, &s3.ListMultipartUploadsInput{Bucket: aws.String(bucketName)},
+		func(page *s3.ListMultipartUploadsOutput, lastPage bool) bool {
+			for _, upload := range page.Uploads {
+				_, abortErr := a.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   aws.String(bucketName),
+					Key:      upload.Key,
+					UploadId: upload.UploadId,
+				})
+				if abortErr != nil {
+					runtime.LogDebug(ContextX, "v1: Failed to abort multipart upload: "+abortErr.Error())
+				}
+			}
+			return true
+		})
+	if err != nil && awsErrorCode(err) != "NotImplemented" {
+		runtime.LogDebug(ContextX, "v1: Failed to list multipart uploads: "+err.Error())
+	}
+
+	progress.Done = true
+	report()
+	if progress.Failed > 0 {
+		return fmt.Errorf("%d object versions in bucket %s could not be deleted (they may be protected by object lock)",
+			progress.Failed, bucketName)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Emptied bucket %s, %d entries deleted", bucketName, progress.Deleted))
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// notificationFilterFromS3 取出过滤规则中的前缀和后缀
+func notificationFilterFromS3(filter *s3.NotificationConfigurationFilter) (prefix, suffix string) {
+	if filter == nil || filter.Key == nil {
+		return "", ""
+	}
+	for _, rule := range filter.Key.FilterRules {
+		switch strings.ToLower(aws.StringValue(rule.Name)) {
+		case s3.FilterRuleNamePrefix:
+			prefix = aws.StringValue(rule.Value)
+		case s3.FilterRuleNameSuffix:
+			suffix = aws.StringValue(rule.Value)
+		}
+	}
+	return prefix, suffix
+}
+
+// notificationFilterToS3 前缀和后缀都为空时返回 nil
+func notificationFilterToS3(prefix, suffix string) *s3.NotificationConfigurationFilter {
+	var rules []*s3.FilterRule
+	if prefix != "" {
+		rules = append(rules, &s3.FilterRule{Name: aws.String(s3.FilterRuleNamePrefix), Value: aws.String(prefix)})
+	}
+	if suffix != "" {
+		rules = append(rules, &s3.FilterRule{Name: aws.String(s3.FilterRuleNameSuffix), Value: aws.String(suffix)})
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return &s3.NotificationConfigurationFilter{Key: &s3.KeyFilter{FilterRules: rules}}
+}
+
+// notificationConfigFromS3 将 SDK 的通知配置转换为 nodes.NotificationConfig
+func notificationConfigFromS3(out *s3.NotificationConfiguration) nodes.NotificationConfig {
+	config := nodes.NotificationConfig{
+		Rules:       []nodes.NotificationRule{},
+		EventBridge: out.EventBridgeConfiguration != nil,
+	}
+	add := func(kind string, id, arn *string, events []*string, filter *s3.NotificationConfigurationFilter) {
+		rule := nodes.NotificationRule{
+			ID:        aws.StringValue(id),
+			Type:      kind,
+			TargetARN: aws.StringValue(arn),
+			Events:    aws.StringValueSlice(events),
+		}
+		rule.Prefix, rule.Suffix = notificationFilterFromS3(filter)
+		config.Rules = append(config.Rules, rule)
+	}
+	for _, c := range out.QueueConfigurations {
+		add(nodes.NotifyQueue, c.Id, c.QueueArn, c.Events, c.Filter)
+	}
+	for _, c := range out.TopicConfigurations {
+		add(nodes.NotifyTopic, c.Id, c.TopicArn, c.Events, c.Filter)
+	}
+	for _, c := range out.LambdaFunctionConfigurations {
+		add(nodes.NotifyLambda, c.Id, c.LambdaFunctionArn, c.Events, c.Filter)
+	}
+	return config
+}
+
+// GetBucketNotification 返回桶的事件通知配置
+func (a *S3Manager) GetBucketNotification(nodeID, bucketName string) (nodes.NotificationConfig, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketNotification: "+err.Error())
+		return nodes.NotificationConfig{}, err
+	}
+	out, err := s3Manager.client.GetBucketNotificationConfigurationWithContext(context.Background(),
+		&s3.GetBucketNotificationConfigurationRequest{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to get bucket notification", err)
+		return nodes.NotificationConfig{}, fmt.Errorf("v1: failed to get notification config for %s: %v", bucketName, err)
+	}
+	return notificationConfigFromS3(out), nil
+}
+
+// ValidateBucketNotification 在本地检查通知配置，返回发现的问题列表
+func (a *S3Manager) ValidateBucketNotification(config nodes.NotificationConfig) []string {
+	return nodes.ValidateNotification(config)
+}
+
+// PutBucketNotification 校验通过后用 config 替换桶的事件通知配置，规则为空表示关闭所有通知
+func (a *S3Manager) PutBucketNotification(nodeID, bucketName string, config nodes.NotificationConfig) error {
+	if problems := nodes.ValidateNotification(config); len(problems) > 0 {
+		return fmt.Errorf("notification configuration is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketNotification: "+err.Error())
+		return err
+	}
+
+	notification := &s3.NotificationConfiguration{}
+	if config.EventBridge {
+		notification.EventBridgeConfiguration = &s3.EventBridgeConfiguration{}
+	}
+	for _, rule := range config.Rules {
+		id := optionalString(rule.ID)
+		events := aws.StringSlice(rule.Events)
+		filter := notificationFilterToS3(rule.Prefix, rule.Suffix)
+		switch rule.Type {
+		case nodes.NotifyQueue:
+			notification.QueueConfigurations = append(notification.QueueConfigurations, &s3.QueueConfiguration{
+				Id: id, QueueArn: aws.String(rule.TargetARN), Events: events, Filter: filter,
+			})
+		case nodes.NotifyTopic:
+			notification.TopicConfigurations = append(notification.TopicConfigurations, &s3.TopicConfiguration{
+				Id: id, TopicArn: aws.String(rule.TargetARN), Events: events, Filter: filter,
+			})
+		case nodes.NotifyLambda:
+			notification.LambdaFunctionConfigurations = append(notification.LambdaFunctionConfigurations, &s3.LambdaFunctionConfiguration{
+				Id: id, LambdaFunctionArn: aws.String(rule.TargetARN), Events: events, Filter: filter,
+			})
+		}
+	}
+
+	_, err = s3Manager.client.PutBucketNotificationConfigurationWithContext(context.Background(),
+		&s3.PutBucketNotificationConfigurationInput{
+			Bucket:                    aws.String(bucketName),
+			NotificationConfiguration: notification,
+		})
+	if err != nil {
+		logAWSError("Failed to put bucket notification", err)
+		return fmt.Errorf("v1: failed to put notification config for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s now has %d notification rules", bucketName, len(config.Rules)))
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// GetBucketPolicy 返回格式化后的桶策略 JSON，未配置策略时返回空字符串
+func (a *S3Manager) GetBucketPolicy(nodeID, bucketName string) (string, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketPolicy: "+err.Error())
+		return "", err
+	}
+	out, err := s3Manager.client.GetBucketPolicyWithContext(context.Background(),
+		&s3.GetBucketPolicyInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == "NoSuchBucketPolicy" {
+			return "", nil
+		}
+		logAWSError("Failed to get bucket policy", err)
+		return "", fmt.Errorf("v1: failed to get policy for %s: %v", bucketName, err)
+	}
+	return prettyPolicy(aws.StringValue(out.Policy)), nil
+}
+
+// ValidateBucketPolicy 在本地检查策略，返回发现的问题列表
+func (a *S3Manager) ValidateBucketPolicy(bucketName, policy string) []string {
+	return nodes.ValidatePolicy(bucketName, policy)
+}
+
+// PutBucketPolicy 校验通过后上传桶策略
+func (a *S3Manager) PutBucketPolicy(nodeID, bucketName, policy string) error {
+	if problems := nodes.ValidatePolicy(bucketName, policy); len(problems) > 0 {
+		return fmt.Errorf("policy is invalid:\n%s", strings.Join(problems, "\n"))
+	}
+
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketPolicy: "+err.Error())
+		return err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(policy)); err != nil {
+		return fmt.Errorf("policy is not valid JSON: %v", err)
+	}
+	_, err = s3Manager.client.PutBucketPolicyWithContext(context.Background(), &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(compact.String()),
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket policy", err)
+		return fmt.Errorf("v1: failed to put policy for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Bucket policy updated for "+bucketName)
+	return nil
+}
+
+// DeleteBucketPolicy 删除桶策略
+func (a *S3Manager) DeleteBucketPolicy(nodeID, bucketName string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteBucketPolicy: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeleteBucketPolicyWithContext(context.Background(),
+		&s3.DeleteBucketPolicyInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to delete bucket policy", err)
+		return fmt.Errorf("v1: failed to delete policy for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Bucket policy deleted for "+bucketName)
+	return nil
+}
+
+// prettyPolicy 缩进格式化策略 JSON，无法解析时原样返回
+func prettyPolicy(policy string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(policy), "", "  "); err != nil {
+		return policy
+	}
+	return out.String()
+}
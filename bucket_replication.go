@@ -0,0 +1,216 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// errCodeNoReplication 桶未配置复制时返回的错误码
+const errCodeNoReplication = "ReplicationConfigurationNotFoundError"
+
+// replicationConfigFromS3 将 SDK 的复制配置转换为 nodes.ReplicationConfig
+func replicationConfigFromS3(in *s3.ReplicationConfiguration) nodes.ReplicationConfig {
+	config := nodes.ReplicationConfig{Rules: []nodes.ReplicationRule{}}
+	if in == nil {
+		return config
+	}
+	config.Role = aws.StringValue(in.Role)
+	for _, r := range in.Rules {
+		rule := nodes.ReplicationRule{
+			ID:       aws.StringValue(r.ID),
+			Priority: aws.Int64Value(r.Priority),
+			Status:   aws.StringValue(r.Status),
+			Prefix:   aws.StringValue(r.Prefix), // 旧版规则直接使用 Prefix
+		}
+		if f := r.Filter; f != nil {
+			switch {
+			case f.And != nil:
+				rule.Prefix = aws.StringValue(f.And.Prefix)
+				rule.Tags = tagsFromS3(f.And.Tags)
+			case f.Tag != nil:
+				rule.Tags = tagsFromS3([]*s3.Tag{f.Tag})
+			default:
+				rule.Prefix = aws.StringValue(f.Prefix)
+			}
+		}
+		if d := r.Destination; d != nil {
+			rule.DestinationBucket = aws.StringValue(d.Bucket)
+			rule.StorageClass = aws.StringValue(d.StorageClass)
+			rule.DestinationAccount = aws.StringValue(d.Account)
+			if d.AccessControlTranslation != nil {
+				rule.OwnerOverride = aws.StringValue(d.AccessControlTranslation.Owner) == s3.OwnerOverrideDestination
+			}
+			if d.EncryptionConfiguration != nil {
+				rule.ReplicaKMSKeyID = aws.StringValue(d.EncryptionConfiguration.ReplicaKmsKeyID)
+			}
+			if d.ReplicationTime != nil && aws.StringValue(d.ReplicationTime.Status) == s3.ReplicationTimeStatusEnabled {
+				rule.ReplicationTimeMinutes = 15 // S3 只支持 15 分钟
+				if d.ReplicationTime.Time != nil {
+					rule.ReplicationTimeMinutes = aws.Int64Value(d.ReplicationTime.Time.Minutes)
+				}
+			}
+			if d.Metrics != nil && aws.StringValue(d.Metrics.Status) == s3.MetricsStatusEnabled {
+				rule.Metrics = true
+				if d.Metrics.EventThreshold != nil {
+					rule.MetricsThresholdMinutes = aws.Int64Value(d.Metrics.EventThreshold.Minutes)
+				}
+			}
+		}
+		if r.DeleteMarkerReplication != nil {
+			rule.DeleteMarkerReplication = aws.StringValue(r.DeleteMarkerReplication.Status) == s3.DeleteMarkerReplicationStatusEnabled
+		}
+		if c := r.SourceSelectionCriteria; c != nil {
+			if c.SseKmsEncryptedObjects != nil {
+				rule.ReplicateKMSEncrypted = aws.StringValue(c.SseKmsEncryptedObjects.Status) == s3.SseKmsEncryptedObjectsStatusEnabled
+			}
+			if c.ReplicaModifications != nil {
+				rule.ReplicaModifications = aws.StringValue(c.ReplicaModifications.Status) == s3.ReplicaModificationsStatusEnabled
+			}
+		}
+		if r.ExistingObjectReplication != nil {
+			rule.ExistingObjects = aws.StringValue(r.ExistingObjectReplication.Status) == s3.ExistingObjectReplicationStatusEnabled
+		}
+		config.Rules = append(config.Rules, rule)
+	}
+	return config
+}
+
+// replicationRuleToS3 使用带 Filter 的新版规则格式，以支持标签过滤和删除标记复制
+func replicationRuleToS3(rule nodes.ReplicationRule) *s3.ReplicationRule {
+	out := &s3.ReplicationRule{
+		ID:                      optionalString(rule.ID),
+		Priority:                aws.Int64(rule.Priority),
+		Status:                  aws.String(rule.Status),
+		Destination:             &s3.Destination{Bucket: aws.String(rule.DestinationARN())},
+		DeleteMarkerReplication: &s3.DeleteMarkerReplication{Status: aws.String(s3.DeleteMarkerReplicationStatusDisabled)},
+	}
+	if rule.StorageClass != "" {
+		out.Destination.StorageClass = aws.String(rule.StorageClass)
+	}
+	if rule.DeleteMarkerReplication {
+		out.DeleteMarkerReplication.Status = aws.String(s3.DeleteMarkerReplicationStatusEnabled)
+	}
+	replicationDestinationToS3(rule, out.Destination)
+
+	if rule.ReplicateKMSEncrypted || rule.ReplicaModifications {
+		out.SourceSelectionCriteria = &s3.SourceSelectionCriteria{}
+		if rule.ReplicateKMSEncrypted {
+			out.SourceSelectionCriteria.SseKmsEncryptedObjects = &s3.SseKmsEncryptedObjects{
+				Status: aws.String(s3.SseKmsEncryptedObjectsStatusEnabled),
+			}
+		}
+		if rule.ReplicaModifications {
+			out.SourceSelectionCriteria.ReplicaModifications = &s3.ReplicaModifications{
+				Status: aws.String(s3.ReplicaModificationsStatusEnabled),
+			}
+		}
+	}
+	if rule.ExistingObjects {
+		out.ExistingObjectReplication = &s3.ExistingObjectReplication{
+			Status: aws.String(s3.ExistingObjectReplicationStatusEnabled),
+		}
+	}
+
+	switch {
+	case len(rule.Tags) > 1 || (len(rule.Tags) == 1 && rule.Prefix != ""):
+		out.Filter = &s3.ReplicationRuleFilter{And: &s3.ReplicationRuleAndOperator{
+			Prefix: optionalString(rule.Prefix),
+			Tags:   tagsToS3(rule.Tags),
+		}}
+	case len(rule.Tags) == 1:
+		out.Filter = &s3.ReplicationRuleFilter{Tag: tagsToS3(rule.Tags)[0]}
+	default:
+		out.Filter = &s3.ReplicationRuleFilter{Prefix: aws.String(rule.Prefix)}
+	}
+	return out
+}
+
+// replicationDestinationToS3 填写目标的账号、所有权、副本加密、复制时间控制和指标设置
+func replicationDestinationToS3(rule nodes.ReplicationRule, d *s3.Destination) {
+	d.Account = optionalString(rule.DestinationAccount)
+	if rule.OwnerOverride {
+		d.AccessControlTranslation = &s3.AccessControlTranslation{Owner: aws.String(s3.OwnerOverrideDestination)}
+	}
+	if rule.ReplicaKMSKeyID != "" {
+		d.EncryptionConfiguration = &s3.EncryptionConfiguration{ReplicaKmsKeyID: aws.String(rule.ReplicaKMSKeyID)}
+	}
+	if rule.ReplicationTimeMinutes > 0 {
+		d.ReplicationTime = &s3.ReplicationTime{
+			Status: aws.String(s3.ReplicationTimeStatusEnabled),
+			Time:   &s3.ReplicationTimeValue{Minutes: aws.Int64(rule.ReplicationTimeMinutes)},
+		}
+	}
+	if rule.Metrics {
+		d.Metrics = &s3.Metrics{Status: aws.String(s3.MetricsStatusEnabled)}
+		if rule.MetricsThresholdMinutes > 0 {
+			d.Metrics.EventThreshold = &s3.ReplicationTimeValue{Minutes: aws.Int64(rule.MetricsThresholdMinutes)}
+		}
+	}
+}
+
+// GetBucketReplication 返回桶的复制配置，未配置时规则列表为空
+func (a *S3Manager) GetBucketReplication(nodeID, bucketName string) (nodes.ReplicationConfig, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketReplication: "+err.Error())
+		return nodes.ReplicationConfig{}, err
+	}
+	out, err := s3Manager.client.GetBucketReplicationWithContext(context.Background(),
+		&s3.GetBucketReplicationInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == errCodeNoReplication {
+			return replicationConfigFromS3(nil), nil
+		}
+		logAWSError("Failed to get bucket replication", err)
+		return nodes.ReplicationConfig{}, fmt.Errorf("v1: failed to get replication for %s: %v", bucketName, err)
+	}
+	return replicationConfigFromS3(out.ReplicationConfiguration), nil
+}
+
+// PutBucketReplication 用 config 替换桶的复制配置，源桶和目标桶都需要启用版本控制
+func (a *S3Manager) PutBucketReplication(nodeID, bucketName string, config nodes.ReplicationConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketReplication: "+err.Error())
+		return err
+	}
+	replication := &s3.ReplicationConfiguration{Role: aws.String(config.Role)}
+	for _, rule := range config.Rules {
+		replication.Rules = append(replication.Rules, replicationRuleToS3(rule))
+	}
+	_, err = s3Manager.client.PutBucketReplicationWithContext(context.Background(), &s3.PutBucketReplicationInput{
+		Bucket:                   aws.String(bucketName),
+		ReplicationConfiguration: replication,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket replication", err)
+		return fmt.Errorf("v1: failed to put replication for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s now has %d replication rules", bucketName, len(config.Rules)))
+	return nil
+}
+
+// DeleteBucketReplication 删除桶的复制配置
+func (a *S3Manager) DeleteBucketReplication(nodeID, bucketName string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteBucketReplication: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeleteBucketReplicationWithContext(context.Background(),
+		&s3.DeleteBucketReplicationInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to delete bucket replication", err)
+		return fmt.Errorf("v1: failed to delete replication for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Replication configuration removed for "+bucketName)
+	return nil
+}
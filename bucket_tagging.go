@@ -0,0 +1,199 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// objectTagConcurrency ListObjects 读取对象标签时的最大并发请求数
+const objectTagConcurrency = 8
+
+func tagsFromS3(in []*s3.Tag) []nodes.Tag {
+	tags := make([]nodes.Tag, 0, len(in))
+	for _, tag := range in {
+		tags = append(tags, nodes.Tag{Key: aws.StringValue(tag.Key), Value: aws.StringValue(tag.Value)})
+	}
+	return tags
+}
+
+func tagsToS3(in []nodes.Tag) []*s3.Tag {
+	var tags []*s3.Tag
+	for _, tag := range in {
+		tags = append(tags, &s3.Tag{Key: aws.String(tag.Key), Value: aws.String(tag.Value)})
+	}
+	return tags
+}
+
+// GetBucketTags 返回桶标签，未设置时返回空列表
+func (a *S3Manager) GetBucketTags(nodeID, bucketName string) ([]nodes.Tag, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketTags: "+err.Error())
+		return nil, err
+	}
+	out, err := s3Manager.client.GetBucketTaggingWithContext(context.Background(),
+		&s3.GetBucketTaggingInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == "NoSuchTagSet" {
+			return []nodes.Tag{}, nil
+		}
+		logAWSError("Failed to get bucket tags", err)
+		return nil, fmt.Errorf("v1: failed to get tags for %s: %v", bucketName, err)
+	}
+	return tagsFromS3(out.TagSet), nil
+}
+
+// PutBucketTags 用 tags 替换桶的全部标签，tags 为空时删除桶标签
+func (a *S3Manager) PutBucketTags(nodeID, bucketName string, tags []nodes.Tag) error {
+	if len(tags) == 0 {
+		return a.DeleteBucketTags(nodeID, bucketName)
+	}
+	if err := nodes.ValidateTags(tags, nodes.MaxBucketTags); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketTags: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutBucketTaggingWithContext(context.Background(), &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Tagging: &s3.Tagging{TagSet: tagsToS3(tags)},
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket tags", err)
+		return fmt.Errorf("v1: failed to put tags for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Bucket %s now has %d tags", bucketName, len(tags)))
+	return nil
+}
+
+// DeleteBucketTags 删除桶的全部标签
+func (a *S3Manager) DeleteBucketTags(nodeID, bucketName string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteBucketTags: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeleteBucketTaggingWithContext(context.Background(),
+		&s3.DeleteBucketTaggingInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to delete bucket tags", err)
+		return fmt.Errorf("v1: failed to delete tags for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Tags removed from bucket "+bucketName)
+	return nil
+}
+
+// GetObjectTags 返回对象标签
+func (a *S3Manager) GetObjectTags(nodeID, bucketName, objectKey string) ([]nodes.Tag, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetObjectTags: "+err.Error())
+		return nil, err
+	}
+	tags, err := s3Manager.objectTags(context.Background(), bucketName, objectKey)
+	if err != nil {
+		logAWSError("Failed to get object tags", err)
+		return nil, fmt.Errorf("v1: failed to get tags for %s/%s: %v", bucketName, objectKey, err)
+	}
+	return tags, nil
+}
+
+// PutObjectTags 用 tags 替换对象的全部标签，tags 为空时删除对象标签
+func (a *S3Manager) PutObjectTags(nodeID, bucketName, objectKey string, tags []nodes.Tag) error {
+	if len(tags) == 0 {
+		return a.DeleteObjectTags(nodeID, bucketName, objectKey)
+	}
+	if err := nodes.ValidateTags(tags, nodes.MaxObjectTags); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutObjectTags: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutObjectTaggingWithContext(context.Background(), &s3.PutObjectTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Key:     aws.String(objectKey),
+		Tagging: &s3.Tagging{TagSet: tagsToS3(tags)},
+	})
+	if err != nil {
+		logAWSError("Failed to put object tags", err)
+		return fmt.Errorf("v1: failed to put tags for %s/%s: %v", bucketName, objectKey, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Object %s/%s now has %d tags", bucketName, objectKey, len(tags)))
+	return nil
+}
+
+// DeleteObjectTags 删除对象的全部标签
+func (a *S3Manager) DeleteObjectTags(nodeID, bucketName, objectKey string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteObjectTags: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeleteObjectTaggingWithContext(context.Background(),
+		&s3.DeleteObjectTaggingInput{Bucket: aws.String(bucketName), Key: aws.String(objectKey)})
+	if err != nil {
+		logAWSError("Failed to delete object tags", err)
+		return fmt.Errorf("v1: failed to delete tags for %s/%s: %v", bucketName, objectKey, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Tags removed from object %s/%s", bucketName, objectKey))
+	return nil
+}
+
+// objectTags 读取并转换对象标签
+func (a *S3Manager) objectTags(ctx context.Context, bucketName, objectKey string) ([]nodes.Tag, error) {
+	out, err := a.client.GetObjectTaggingWithContext(ctx,
+		&s3.GetObjectTaggingInput{Bucket: aws.String(bucketName), Key: aws.String(objectKey)})
+	if err != nil {
+		return nil, err
+	}
+	return tagsFromS3(out.TagSet), nil
+}
+
+// fillObjectTags 以有限并发为每个对象读取标签，单个对象失败只记录调试日志。
+// 服务不支持对象标签或无权读取时，第一次失败后即停止，不再逐个请求
+func (a *S3Manager) fillObjectTags(ctx context.Context, bucketName string, objects []ObjectInfo) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stopOnce sync.Once
+
+	sem := make(chan struct{}, objectTagConcurrency)
+	var wg sync.WaitGroup
+	for i := range objects {
+		if !acquire(ctx, sem) {
+			break
+		}
+		wg.Add(1)
+		go func(info *ObjectInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			tags, err := a.objectTags(ctx, bucketName, info.Key)
+			if err == nil {
+				info.Tags = tags
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			switch awsErrorCode(err) {
+			case "NotImplemented", "AccessDenied", "MethodNotAllowed":
+				stopOnce.Do(func() {
+					runtime.LogDebug(ContextX, fmt.Sprintf("v1: Object tags unavailable in %s, skipping the rest: %s", bucketName, err.Error()))
+					cancel()
+				})
+			default:
+				runtime.LogDebug(ContextX, fmt.Sprintf("v1: Failed to get tags for %s/%s: %s", bucketName, info.Key, err.Error()))
+			}
+		}(&objects[i])
+	}
+	wg.Wait()
+}
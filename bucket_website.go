@@ -0,0 +1,453 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	file "SRSC-Client/utils"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// deployProgressEvent 部署静态网站时发送给前端的进度事件，数据为当前的 DeployResult
+const deployProgressEvent = "site:deploy-progress"
+
+// siteContentTypes 常见网页文件的 Content-Type，避免依赖系统的 MIME 数据库
+var siteContentTypes = map[string]string{
+	".html":        "text/html; charset=utf-8",
+	".htm":         "text/html; charset=utf-8",
+	".css":         "text/css; charset=utf-8",
+	".js":          "text/javascript; charset=utf-8",
+	".mjs":         "text/javascript; charset=utf-8",
+	".json":        "application/json",
+	".map":         "application/json",
+	".xml":         "application/xml",
+	".txt":         "text/plain; charset=utf-8",
+	".md":          "text/markdown; charset=utf-8",
+	".svg":         "image/svg+xml",
+	".png":         "image/png",
+	".jpg":         "image/jpeg",
+	".jpeg":        "image/jpeg",
+	".gif":         "image/gif",
+	".webp":        "image/webp",
+	".avif":        "image/avif",
+	".ico":         "image/x-icon",
+	".woff":        "font/woff",
+	".woff2":       "font/woff2",
+	".ttf":         "font/ttf",
+	".otf":         "font/otf",
+	".wasm":        "application/wasm",
+	".pdf":         "application/pdf",
+	".webmanifest": "application/manifest+json",
+}
+
+// siteNoCacheTypes 这些扩展名的文件内容随发布变化，浏览器每次都应重新验证
+var siteNoCacheTypes = map[string]bool{
+	".html": true, ".htm": true, ".json": true, ".xml": true, ".txt": true, ".webmanifest": true,
+}
+
+// websiteConfigFromS3 将 GetBucketWebsite 的结果转换为 nodes.WebsiteConfig
+func websiteConfigFromS3(out *s3.GetBucketWebsiteOutput) nodes.WebsiteConfig {
+	config := nodes.WebsiteConfig{Enabled: true}
+	if out.IndexDocument != nil {
+		config.IndexDocument = aws.StringValue(out.IndexDocument.Suffix)
+	}
+	if out.ErrorDocument != nil {
+		config.ErrorDocument = aws.StringValue(out.ErrorDocument.Key)
+	}
+	if r := out.RedirectAllRequestsTo; r != nil {
+		config.RedirectAllTo = &nodes.WebsiteRedirect{
+			HostName: aws.StringValue(r.HostName),
+			Protocol: aws.StringValue(r.Protocol),
+		}
+	}
+	for _, rule := range out.RoutingRules {
+		var routing nodes.RoutingRule
+		if c := rule.Condition; c != nil {
+			routing.Condition = nodes.RoutingCondition{
+				KeyPrefixEquals:             aws.StringValue(c.KeyPrefixEquals),
+				HttpErrorCodeReturnedEquals: aws.StringValue(c.HttpErrorCodeReturnedEquals),
+			}
+		}
+		if r := rule.Redirect; r != nil {
+			routing.Redirect = nodes.WebsiteRedirect{
+				HostName:             aws.StringValue(r.HostName),
+				Protocol:             aws.StringValue(r.Protocol),
+				HttpRedirectCode:     aws.StringValue(r.HttpRedirectCode),
+				ReplaceKeyPrefixWith: aws.StringValue(r.ReplaceKeyPrefixWith),
+				ReplaceKeyWith:       aws.StringValue(r.ReplaceKeyWith),
+			}
+		}
+		config.RoutingRules = append(config.RoutingRules, routing)
+	}
+	return config
+}
+
+// optionalString 空字符串返回 nil，使 SDK 不发送该字段
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
+
+// GetBucketWebsite 返回桶的静态网站配置，未启用时 Enabled 为 false
+func (a *S3Manager) GetBucketWebsite(nodeID, bucketName string) (nodes.WebsiteConfig, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetBucketWebsite: "+err.Error())
+		return nodes.WebsiteConfig{}, err
+	}
+	out, err := s3Manager.client.GetBucketWebsiteWithContext(context.Background(),
+		&s3.GetBucketWebsiteInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == "NoSuchWebsiteConfiguration" {
+			return nodes.WebsiteConfig{}, nil
+		}
+		logAWSError("Failed to get bucket website", err)
+		return nodes.WebsiteConfig{}, fmt.Errorf("v1: failed to get website config for %s: %v", bucketName, err)
+	}
+	return websiteConfigFromS3(out), nil
+}
+
+// PutBucketWebsite 设置桶的静态网站配置
+func (a *S3Manager) PutBucketWebsite(nodeID, bucketName string, config nodes.WebsiteConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutBucketWebsite: "+err.Error())
+		return err
+	}
+
+	website := &s3.WebsiteConfiguration{}
+	if r := config.RedirectAllTo; r != nil {
+		website.RedirectAllRequestsTo = &s3.RedirectAllRequestsTo{
+			HostName: aws.String(r.HostName),
+			Protocol: optionalString(r.Protocol),
+		}
+	} else {
+		website.IndexDocument = &s3.IndexDocument{Suffix: aws.String(config.IndexDocument)}
+		if config.ErrorDocument != "" {
+			website.ErrorDocument = &s3.ErrorDocument{Key: aws.String(config.ErrorDocument)}
+		}
+	}
+	for _, rule := range config.RoutingRules {
+		routing := &s3.RoutingRule{Redirect: &s3.Redirect{
+			HostName:             optionalString(rule.Redirect.HostName),
+			Protocol:             optionalString(rule.Redirect.Protocol),
+			HttpRedirectCode:     optionalString(rule.Redirect.HttpRedirectCode),
+			ReplaceKeyPrefixWith: optionalString(rule.Redirect.ReplaceKeyPrefixWith),
+			ReplaceKeyWith:       optionalString(rule.Redirect.ReplaceKeyWith),
+		}}
+		if c := rule.Condition; c.KeyPrefixEquals != "" || c.HttpErrorCodeReturnedEquals != "" {
+			routing.Condition = &s3.Condition{
+				KeyPrefixEquals:             optionalString(c.KeyPrefixEquals),
+				HttpErrorCodeReturnedEquals: optionalString(c.HttpErrorCodeReturnedEquals),
+			}
+		}
+		website.RoutingRules = append(website.RoutingRules, routing)
+	}
+
+	_, err = s3Manager.client.PutBucketWebsiteWithContext(context.Background(), &s3.PutBucketWebsiteInput{
+		Bucket:               aws.String(bucketName),
+		WebsiteConfiguration: website,
+	})
+	if err != nil {
+		logAWSError("Failed to put bucket website", err)
+		return fmt.Errorf("v1: failed to put website config for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Website configuration updated for "+bucketName)
+	return nil
+}
+
+// DeleteBucketWebsite 关闭桶的静态网站
+func (a *S3Manager) DeleteBucketWebsite(nodeID, bucketName string) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteBucketWebsite: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.DeleteBucketWebsiteWithContext(context.Background(),
+		&s3.DeleteBucketWebsiteInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		logAWSError("Failed to delete bucket website", err)
+		return fmt.Errorf("v1: failed to delete website config for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, "v1: Website configuration removed for "+bucketName)
+	return nil
+}
+
+// siteFile 待部署的本地文件
+type siteFile struct {
+	path string // 本地路径
+	key  string // 对象键
+}
+
+// DeploySite 将选择的本地文件夹部署到桶的 prefix 下：上传新增或修改的文件，返回网站访问地址。
+// prefix 下本地已不存在的对象列在 Stale 中，只有 deleteStale 为 true 时才会删除；
+// 以 . 开头的路径(如 .well-known)不会上传，也不会被当作过期对象
+func (a *S3Manager) DeploySite(nodeID, bucketName, prefix string, deleteStale bool) (nodes.DeployResult, error) {
+	result := nodes.DeployResult{Stale: []string{}, Failed: []string{}}
+
+	dir := file.GetDirPath(ContextX)
+	if dir == "" {
+		return result, fmt.Errorf("folder selection cancelled or no folder chosen")
+	}
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		prefix += "/"
+	}
+
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeploySite: "+err.Error())
+		return result, err
+	}
+	ctx := context.Background()
+
+	files, err := collectSiteFiles(dir, prefix)
+	if err != nil {
+		return result, fmt.Errorf("v1: unable to read folder %s: %v", dir, err)
+	}
+	if len(files) == 0 {
+		return result, fmt.Errorf("folder %s contains no files to deploy", dir)
+	}
+
+	// 记录远端已有对象的 ETag，用于跳过未修改的文件和找出过期文件
+	remote := make(map[string]string)
+	err = s3Manager.client.ListObjectsV2PagesWithContext(ctx,
+		&s3.ListObjectsV2Input{Bucket: aws.String(bucketName), Prefix: aws.String(prefix)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				remote[aws.StringValue(obj.Key)] = strings.Trim(aws.StringValue(obj.ETag), `"`)
+			}
+			return true
+		})
+	if err != nil {
+		logAWSError("Failed to list existing site files", err)
+		return result, fmt.Errorf("v1: failed to list objects in %s: %v", bucketName, err)
+	}
+
+	report := func() {
+		runtime.EventsEmit(ContextX, deployProgressEvent, result)
+	}
+
+	for _, f := range files {
+		etag, present := remote[f.key]
+		delete(remote, f.key)
+		uploaded, err := s3Manager.uploadSiteFile(ctx, bucketName, f, etag, present)
+		switch {
+		case err != nil:
+			runtime.LogError(ContextX, fmt.Sprintf("v1: Failed to upload %s: %s", f.key, err.Error()))
+			result.Failed = append(result.Failed, f.key)
+		case uploaded:
+			result.Uploaded++
+		default:
+			result.Unchanged++
+		}
+		report()
+	}
+
+	// 剩下的远端对象在本地已不存在；本地跳过的隐藏路径保留
+	var stale []*s3.ObjectIdentifier
+	for key := range remote {
+		if hiddenSitePath(strings.TrimPrefix(key, prefix)) {
+			continue
+		}
+		result.Stale = append(result.Stale, key)
+		stale = append(stale, &s3.ObjectIdentifier{Key: aws.String(key)})
+	}
+	sort.Strings(result.Stale)
+	if !deleteStale {
+		stale = nil
+	}
+	for len(stale) > 0 {
+		batch := stale
+		if len(batch) > 1000 {
+			batch = batch[:1000]
+		}
+		stale = stale[len(batch):]
+		out, err := s3Manager.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &s3.Delete{Objects: batch, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			logAWSError("Failed to delete stale site files", err)
+			for _, id := range batch {
+				result.Failed = append(result.Failed, aws.StringValue(id.Key))
+			}
+			continue
+		}
+		for _, e := range out.Errors {
+			result.Failed = append(result.Failed, aws.StringValue(e.Key))
+		}
+		result.Deleted += len(batch) - len(out.Errors)
+		report()
+	}
+
+	result.Endpoint = websiteEndpoint(s3Manager.node, bucketName, prefix)
+	runtime.LogInfo(ContextX, fmt.Sprintf("v1: Deployed %s to %s: %d uploaded, %d unchanged, %d stale, %d deleted, %d failed. Website: %s",
+		dir, bucketName, result.Uploaded, result.Unchanged, len(result.Stale), result.Deleted, len(result.Failed), result.Endpoint))
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("%d files could not be deployed", len(result.Failed))
+	}
+	return result, nil
+}
+
+// hiddenSitePath 相对路径中是否有以 . 开头的部分，这些路径不参与部署
+func hiddenSitePath(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// collectSiteFiles 遍历本地文件夹，跳过以 . 开头的文件和目录(如 .git)
+func collectSiteFiles(dir, prefix string) ([]siteFile, error) {
+	var files []siteFile
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, siteFile{path: p, key: prefix + filepath.ToSlash(rel)})
+		return nil
+	})
+	return files, err
+}
+
+// uploadSiteFile 上传单个文件；远端 ETag 与本地 MD5 相同时跳过，返回是否实际上传
+func (a *S3Manager) uploadSiteFile(ctx context.Context, bucketName string, f siteFile, etag string, present bool) (bool, error) {
+	fh, err := os.Open(f.path)
+	if err != nil {
+		return false, err
+	}
+	defer fh.Close()
+
+	hash := md5.New()
+	size, err := io.Copy(hash, fh)
+	if err != nil {
+		return false, err
+	}
+	if present && etag == hex.EncodeToString(hash.Sum(nil)) {
+		return false, nil
+	}
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	ext := strings.ToLower(path.Ext(f.key))
+	contentType, err := siteContentType(fh, ext)
+	if err != nil {
+		return false, err
+	}
+	cacheControl := "public, max-age=86400"
+	if siteNoCacheTypes[ext] {
+		cacheControl = "no-cache"
+	}
+
+	_, err = a.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucketName),
+		Key:           aws.String(f.key),
+		Body:          fh,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+		CacheControl:  aws.String(cacheControl),
+	})
+	if err != nil {
+		return false, err
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Uploaded %s (%s, %s)", f.key, contentType, cacheControl))
+	return true, nil
+}
+
+// siteContentType 按扩展名确定 Content-Type，未知扩展名时根据文件内容检测，完成后文件指针回到开头
+func siteContentType(fh *os.File, ext string) (string, error) {
+	if contentType, ok := siteContentTypes[ext]; ok {
+		return contentType, nil
+	}
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType, nil
+	}
+	buffer := make([]byte, 512)
+	n, err := fh.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(buffer[:n]), nil
+}
+
+// awsWebsiteDashRegions 使用 "s3-website-<region>" 形式端点的旧区域，其余区域使用 "s3-website.<region>"
+var awsWebsiteDashRegions = map[string]bool{
+	"us-east-1": true, "us-west-1": true, "us-west-2": true, "eu-west-1": true, "sa-east-1": true,
+	"ap-southeast-1": true, "ap-southeast-2": true, "ap-northeast-1": true, "us-gov-west-1": true,
+}
+
+// websiteEndpoint 返回桶的静态网站地址。AWS 使用专门的网站端点，
+// 其他兼容服务(MinIO、R2 等)直接通过节点端点访问
+func websiteEndpoint(node nodes.Node, bucketName, prefix string) string {
+	endpoint := node.EndPoint
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https://"
+		if node.DisableSSL {
+			scheme = "http://"
+		}
+		endpoint = scheme + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	if strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		region := node.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		separator := "."
+		if awsWebsiteDashRegions[region] {
+			separator = "-"
+		}
+		return fmt.Sprintf("http://%s.s3-website%s%s.amazonaws.com/%s", bucketName, separator, region, prefix)
+	}
+
+	if node.UsePathStyle() {
+		u.Path = path.Join("/", u.Path, bucketName) + "/" + prefix
+	} else {
+		u.Host = bucketName + "." + u.Host
+		u.Path = "/" + prefix
+	}
+	return u.String()
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// s3Clients 全局客户端缓存，所有 S3Manager 方法共用
+var s3Clients = newClientRegistry()
+
+// cachedClient 缓存的客户端及构建它时使用的节点配置
+type cachedClient struct {
+	node   nodes.Node
+	client *s3.S3
+}
+
+// clientRegistry 按节点缓存 S3 客户端，会话只创建一次并复用其连接池
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients map[string]*cachedClient
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: make(map[string]*cachedClient)}
+}
+
+// nodeKey identifies a node in the registry by its stable ID.
+func nodeKey(node nodes.Node) string {
+	return node.ID
+}
+
+// Get returns the cached client for node, building a new session when the
+// node has not been seen before or any of its settings changed.
+func (r *clientRegistry) Get(node nodes.Node) (*s3.S3, error) {
+	key := nodeKey(node)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.clients[key]; ok {
+		if cached.node == node {
+			return cached.client, nil
+		}
+		runtime.LogDebug(ContextX, "v1: Node settings changed, rebuilding client for "+node.EndPoint)
+	}
+
+	client, err := newS3Service(node)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[key] = &cachedClient{node: node, client: client}
+	return client, nil
+}
+
+// Forget drops the cached client for node, if any.
+func (r *clientRegistry) Forget(node nodes.Node) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, nodeKey(node))
+}
+
+// Reset drops every cached client, e.g. when the vault is locked.
+func (r *clientRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = make(map[string]*cachedClient)
+}
+
+// newS3Service builds a fresh session and S3 service client for node
+func newS3Service(node nodes.Node) (*s3.S3, error) {
+	runtime.LogDebug(ContextX, "v1: Creating session for endpoint: "+node.EndPoint)
+
+	if node.AddressingStyle != "" && node.AddressingStyle != nodes.AddressingPath &&
+		node.AddressingStyle != nodes.AddressingVirtual {
+		return nil, fmt.Errorf("v1: unknown addressing style %q", node.AddressingStyle)
+	}
+
+	httpClient, err := newHTTPClient(node)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Failed to configure HTTP client: "+err.Error())
+		return nil, err
+	}
+
+	creds, err := newCredentials(node, httpClient)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Failed to resolve credentials: "+err.Error())
+		return nil, err
+	}
+
+	awsCfg := &aws.Config{
+		Credentials:      creds,
+		Endpoint:         aws.String(node.EndPoint),
+		Region:           aws.String(node.Region),
+		S3ForcePathStyle: aws.Bool(node.UsePathStyle()),
+		DisableSSL:       aws.Bool(node.DisableSSL),
+		HTTPClient:       httpClient,
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Failed to create session: "+err.Error())
+		return nil, fmt.Errorf("v1: unable to create AWS session: %v", err)
+	}
+	runtime.LogDebug(ContextX, "v1: Session created successfully for region: "+node.Region)
+
+	return s3.New(sess), nil
+}
+
+// newHTTPClient builds the HTTP client for node, applying its TLS, proxy
+// and timeout options on top of the default transport.
+func newHTTPClient(node nodes.Node) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if node.Proxy != "" {
+		proxyURL, err := url.Parse(node.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("v1: invalid proxy address %q: %v", node.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if node.ConnectTimeout > 0 {
+		dialer := &net.Dialer{
+			Timeout:   time.Duration(node.ConnectTimeout) * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = dialer.Timeout
+	}
+	if node.ReadTimeout > 0 {
+		transport.ResponseHeaderTimeout = time.Duration(node.ReadTimeout) * time.Second
+	}
+
+	if node.CABundle != "" || node.SkipTLSVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: node.SkipTLSVerify,
+		}
+		if node.CABundle != "" {
+			pem, err := os.ReadFile(node.CABundle)
+			if err != nil {
+				return nil, fmt.Errorf("v1: unable to read CA bundle %s: %v", node.CABundle, err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("v1: no certificates found in CA bundle %s", node.CABundle)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
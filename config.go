@@ -0,0 +1,303 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"SRSC-Client/vault"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	appConfigDir     = "SRSC-Client"   // 用户配置目录下的应用目录名
+	configFileName   = "config.json"   // 配置文件名
+	configEnv        = "SRSC_CONFIG"   // 指定配置文件路径的环境变量
+	legacyConfigPath = "./config.json" // 旧版本使用的相对于工作目录的配置文件
+	migratedSuffix   = ".migrated"     // 迁移后旧配置文件追加的后缀
+)
+
+// configOverride 命令行 -config 参数指定的配置文件路径
+var configOverride string
+
+// configPath 节点配置文件路径，启动时由 initConfigPath 确定
+var configPath = legacyConfigPath
+
+// initConfigPath 确定配置文件路径并在首次运行时创建它。
+// 优先级: -config 参数 > SRSC_CONFIG 环境变量 > 用户配置目录。
+// 使用用户配置目录且其中没有配置文件时，会迁移旧版本留在工作目录或程序目录下的 config.json
+func initConfigPath() error {
+	path := configOverride
+	if path == "" {
+		path = os.Getenv(configEnv)
+	}
+	useDefault := path == ""
+	if useDefault {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return fmt.Errorf("unable to locate user config directory: %v", err)
+		}
+		path = filepath.Join(dir, appConfigDir, configFileName)
+	}
+	configPath = path
+	runtime.LogDebug(ContextX, "Using config file "+configPath)
+
+	if _, err := os.Stat(configPath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		return fmt.Errorf("unable to create config directory: %v", err)
+	}
+	if useDefault {
+		if legacy := findLegacyConfig(); legacy != "" {
+			return migrateLegacyConfig(legacy)
+		}
+	}
+	runtime.LogDebug(ContextX, "Creating empty config file")
+	return writeConfig(&configFile{SchemaVersion: currentSchemaVersion})
+}
+
+// migrateLegacyConfig 在配置锁内解析旧版本的配置文件，升级后原子写入 configPath。
+// 成功后将旧文件重命名为 <旧文件>.migrated，避免再次迁移；启用保险库时由 purgePlaintextCopies 清除
+func migrateLegacyConfig(legacy string) error {
+	return withConfigLock(func() error {
+		if _, err := os.Stat(configPath); err == nil {
+			return nil // 其他实例已完成迁移
+		}
+		content, err := os.ReadFile(legacy)
+		if err != nil {
+			return fmt.Errorf("unable to read legacy config %s: %v", legacy, err)
+		}
+		cfg, _, err := parseConfig(content)
+		if err != nil {
+			return fmt.Errorf("unable to migrate legacy config %s: %v", legacy, err)
+		}
+		if err := writeConfigLocked(cfg); err != nil {
+			return err
+		}
+		if err := os.Rename(legacy, legacy+migratedSuffix); err != nil {
+			runtime.LogWarning(ContextX, fmt.Sprintf("Migrated legacy config %s to %s but could not rename the old file: %v", legacy, configPath, err))
+			return nil
+		}
+		runtime.LogInfo(ContextX, fmt.Sprintf("Migrated legacy config %s to %s; the old file was renamed to %s", legacy, configPath, legacy+migratedSuffix))
+		return nil
+	})
+}
+
+// legacyConfigCandidates 旧版本 config.json 可能的位置(工作目录或程序所在目录)
+func legacyConfigCandidates() []string {
+	candidates := []string{legacyConfigPath}
+	if exe, err := os.Executable(); err == nil {
+		candidates = append(candidates, filepath.Join(filepath.Dir(exe), configFileName))
+	}
+	return candidates
+}
+
+// findLegacyConfig 查找旧版本的 config.json
+func findLegacyConfig() string {
+	for _, candidate := range legacyConfigCandidates() {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// currentSchemaVersion 当前配置文件格式版本，修改格式时递增并在 configMigrations 中追加迁移
+const currentSchemaVersion = 1
+
+// configFile 配置文件结构。版本 0 的旧配置文件是节点数组，或不带 SchemaVersion 的对象
+type configFile struct {
+	SchemaVersion int           `json:"SchemaVersion"`
+	Vault         *vault.Header `json:"Vault,omitempty"`
+	Nodes         []nodes.Node  `json:"Nodes"`
+}
+
+// configMigrations[i] 将配置从版本 i 升级到 i+1
+var configMigrations = []func(cfg *configFile) error{
+	// 0 -> 1: 为节点补充稳定 ID
+	func(cfg *configFile) error {
+		nodes.EnsureIDs(cfg.Nodes)
+		return nil
+	},
+}
+
+// readConfig 读取配置文件，必要时升级到当前版本并写回
+func readConfig() (*configFile, error) {
+	var cfg *configFile
+	err := withConfigLock(func() error {
+		var migrated bool
+		var err error
+		cfg, migrated, err = readConfigLocked()
+		if err == nil && migrated {
+			err = writeConfigLocked(cfg)
+		}
+		return err
+	})
+	return cfg, err
+}
+
+// writeConfig 写入配置文件，节点中的密钥应已按需加密
+func writeConfig(cfg *configFile) error {
+	return withConfigLock(func() error {
+		return writeConfigLocked(cfg)
+	})
+}
+
+// updateConfig 在持有配置锁期间读取配置、交给 update 修改并写回
+func updateConfig(update func(cfg *configFile) error) error {
+	return withConfigLock(func() error {
+		cfg, _, err := readConfigLocked()
+		if err != nil {
+			return err
+		}
+		if err := update(cfg); err != nil {
+			return err
+		}
+		return writeConfigLocked(cfg)
+	})
+}
+
+// readConfigLocked 解析配置文件并升级到当前版本，返回是否进行了升级
+func readConfigLocked() (*configFile, bool, error) {
+	fileContent, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, false, err
+	}
+	return parseConfig(fileContent)
+}
+
+// parseConfig 解析配置内容并升级到当前版本，返回是否进行了升级
+func parseConfig(fileContent []byte) (*configFile, bool, error) {
+	var err error
+	cfg := &configFile{}
+	if bytes.HasPrefix(bytes.TrimSpace(fileContent), []byte("[")) {
+		cfg.Nodes, err = nodes.GetNodes(fileContent)
+	} else {
+		err = json.Unmarshal(fileContent, cfg)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	if cfg.SchemaVersion > currentSchemaVersion {
+		return nil, false, fmt.Errorf("config file schema version %d is newer than supported version %d, please upgrade SRSC-Client",
+			cfg.SchemaVersion, currentSchemaVersion)
+	}
+	migrated := false
+	for cfg.SchemaVersion < currentSchemaVersion {
+		runtime.LogInfo(ContextX, fmt.Sprintf("Migrating config file from schema version %d", cfg.SchemaVersion))
+		if err := configMigrations[cfg.SchemaVersion](cfg); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate config from schema version %d: %v", cfg.SchemaVersion, err)
+		}
+		cfg.SchemaVersion++
+		migrated = true
+	}
+	return cfg, migrated, nil
+}
+
+// loadNodes 读取配置文件中的全部节点。
+// 启用保险库时返回解密后的密钥，保险库锁定时返回 vault.ErrLocked
+func loadNodes() ([]nodes.Node, error) {
+	cfg, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Vault == nil {
+		return cfg.Nodes, nil
+	}
+	key, err := secretKeyring.Key()
+	if err != nil {
+		return nil, err
+	}
+	return openSecrets(key, cfg.Nodes)
+}
+
+// updateNodes 在持有配置锁期间读取节点列表，交给 update 修改后写回，启用保险库时自动解密和加密密钥
+func updateNodes(update func([]nodes.Node) ([]nodes.Node, error)) error {
+	return updateConfig(func(cfg *configFile) error {
+		nodeList := cfg.Nodes
+		var key []byte
+		if cfg.Vault != nil {
+			var err error
+			if key, err = secretKeyring.Key(); err != nil {
+				return err
+			}
+			if nodeList, err = openSecrets(key, nodeList); err != nil {
+				return err
+			}
+		}
+		nodeList, err := update(nodeList)
+		if err != nil {
+			return err
+		}
+		if cfg.Vault != nil {
+			if nodeList, err = sealSecrets(key, nodeList); err != nil {
+				return err
+			}
+		}
+		cfg.Nodes = nodeList
+		return nil
+	})
+}
+
+// sealSecrets 返回密钥已加密的节点副本，密文绑定到节点 ID
+func sealSecrets(key []byte, nodeList []nodes.Node) ([]nodes.Node, error) {
+	sealed := make([]nodes.Node, len(nodeList))
+	for i, node := range nodeList {
+		for _, field := range secretFields(&node) {
+			if *field == "" || vault.IsSealed(*field) {
+				continue
+			}
+			value, err := vault.Seal(key, *field, node.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt secrets of %s: %v", node.NodeName, err)
+			}
+			*field = value
+		}
+		sealed[i] = node
+	}
+	return sealed, nil
+}
+
+// openSecrets 返回密钥已解密的节点副本
+func openSecrets(key []byte, nodeList []nodes.Node) ([]nodes.Node, error) {
+	opened := make([]nodes.Node, len(nodeList))
+	for i, node := range nodeList {
+		for _, field := range secretFields(&node) {
+			if !vault.IsSealed(*field) {
+				continue
+			}
+			value, err := vault.Open(key, *field, node.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt secrets of %s: %v", node.NodeName, err)
+			}
+			*field = value
+		}
+		opened[i] = node
+	}
+	return opened, nil
+}
+
+// secretFields 返回节点中需要加密保存的字段
+func secretFields(node *nodes.Node) []*string {
+	return []*string{&node.SecretKey, &node.SessionToken}
+}
+
+// resolveNode 按 ID 查找已保存的节点，返回的节点包含密钥，只在 Go 侧使用
+func resolveNode(nodeID string) (nodes.Node, error) {
+	nodeList, err := loadNodes()
+	if err != nil {
+		runtime.LogError(ContextX, "Unable to load saved nodes: "+err.Error())
+		return nodes.Node{}, err
+	}
+	i := nodes.FindNode(nodeList, nodeID)
+	if i < 0 {
+		return nodes.Node{}, nodes.ErrNodeNotFound
+	}
+	return nodeList[i], nil
+}
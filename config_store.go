@@ -0,0 +1,227 @@
+package main
+
+import (
+	"SRSC-Client/filelock"
+	nodes "SRSC-Client/type"
+	"SRSC-Client/vault"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxConfigBackups 保留的配置文件备份数量
+const maxConfigBackups = 5
+
+// configMu 串行化本进程内对配置文件的访问，跨进程由锁文件保证
+var configMu sync.Mutex
+
+// withConfigLock 持有进程内互斥锁和配置锁文件期间执行 fn
+func withConfigLock(fn func() error) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	lock, err := filelock.Acquire(configPath + ".lock")
+	if err != nil {
+		return fmt.Errorf("unable to lock config file: %v", err)
+	}
+	defer lock.Release()
+	return fn()
+}
+
+// writeConfigLocked 原子地写入配置文件：先写临时文件并 fsync，再重命名覆盖。
+// 覆盖前将旧文件保存到 backups 目录。启用保险库时不备份明文配置，
+// 并在写入后清除已有的明文备份和旧版本的 config.json。调用方需持有配置锁
+func writeConfigLocked(cfg *configFile) error {
+	if cfg.Nodes == nil {
+		cfg.Nodes = []nodes.Node{}
+	}
+	cfg.SchemaVersion = currentSchemaVersion
+	content, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize node list: %v", err)
+	}
+
+	sealed := cfg.Vault != nil
+	if err := backupConfig(sealed); err != nil {
+		// 备份失败不阻止保存
+		runtime.LogWarning(ContextX, "Failed to back up config file: "+err.Error())
+	}
+
+	dir := filepath.Dir(configPath)
+	tmp, err := os.CreateTemp(dir, ".config-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // 重命名成功后为空操作
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync config file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	if err := os.Chmod(tmpName, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	if err := os.Rename(tmpName, configPath); err != nil {
+		return fmt.Errorf("failed to replace config file: %v", err)
+	}
+	syncDir(dir)
+
+	if sealed {
+		purgePlaintextCopies()
+	}
+	return nil
+}
+
+// syncDir 尽力 fsync 目录以持久化重命名，部分平台不支持时忽略
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	d.Close()
+}
+
+// backupDir 配置文件备份目录
+func backupDir() string {
+	return filepath.Join(filepath.Dir(configPath), "backups")
+}
+
+// configSealed 配置内容是否启用了保险库，即其中的密钥已加密
+func configSealed(content []byte) bool {
+	var header struct {
+		Vault *vault.Header `json:"Vault"`
+	}
+	return json.Unmarshal(content, &header) == nil && header.Vault != nil
+}
+
+// backupConfig 将当前配置文件复制到备份目录，只保留最近 maxConfigBackups 份。
+// sealedOnly 为 true 时跳过未加密的配置
+func backupConfig(sealedOnly bool) error {
+	content, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if sealedOnly && !configSealed(content) {
+		return nil
+	}
+	dir := backupDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	name := "config-" + time.Now().Format("20060102-150405.000000000") + ".json"
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+		return err
+	}
+
+	backups, err := listBackups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > maxConfigBackups {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// listBackups 按时间顺序返回备份文件名
+func listBackups() ([]string, error) {
+	entries, err := os.ReadDir(backupDir())
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "config-") && strings.HasSuffix(entry.Name(), ".json") {
+			backups = append(backups, entry.Name())
+		}
+	}
+	// 文件名中的时间戳使字典序即时间顺序
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// purgePlaintextCopies 删除包含明文密钥的配置备份，以及迁移后遗留的旧版本 config.json(.migrated)。
+// 删除失败只记录日志
+func purgePlaintextCopies() {
+	if backups, err := listBackups(); err == nil {
+		for _, name := range backups {
+			p := filepath.Join(backupDir(), name)
+			if content, err := os.ReadFile(p); err == nil && !configSealed(content) {
+				removePlaintextCopy(p)
+			}
+		}
+	}
+	// 旧版本的配置文件是节点数组，只删除确实保存了密钥的，避免误删工作目录中无关的 config.json。
+	// 迁移时已重命名的旧文件同样处理
+	for _, candidate := range legacyConfigCandidates() {
+		for _, legacy := range []string{candidate, candidate + migratedSuffix} {
+			if sameFile(legacy, configPath) {
+				continue
+			}
+			if content, err := os.ReadFile(legacy); err == nil && legacyHasSecrets(content) {
+				removePlaintextCopy(legacy)
+			}
+		}
+	}
+}
+
+// legacyHasSecrets 内容是否为带明文密钥的旧版本节点数组
+func legacyHasSecrets(content []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		return false
+	}
+	nodeList, err := nodes.GetNodes(content)
+	if err != nil {
+		return false
+	}
+	for _, node := range nodeList {
+		if node.SecretKey != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func removePlaintextCopy(p string) {
+	if err := os.Remove(p); err != nil {
+		runtime.LogWarning(ContextX, "Failed to remove plaintext config copy: "+err.Error())
+		return
+	}
+	runtime.LogInfo(ContextX, "Removed plaintext config copy "+p)
+}
+
+// sameFile 两个路径是否指向同一文件
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
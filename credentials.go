@@ -0,0 +1,100 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// processExpiryWindow refreshes credential_process and assumed-role
+// credentials this long before they actually expire, so in-flight requests
+// don't fail.
+const processExpiryWindow = time.Minute
+
+// defaultRoleSessionName is used when a node sets RoleARN without a session name
+const defaultRoleSessionName = "srsc-client"
+
+// newCredentials builds the credentials for node. When the node sets RoleARN
+// the base credentials are exchanged for the role through STS; the role
+// session is cached by the SDK and renewed shortly before it expires.
+func newCredentials(node nodes.Node, httpClient *http.Client) (*credentials.Credentials, error) {
+	base, err := baseCredentials(node)
+	if err != nil || node.RoleARN == "" {
+		return base, err
+	}
+	return assumeRoleCredentials(node, base, httpClient)
+}
+
+// baseCredentials builds the credentials for node from its credential source.
+// Everything is resolved through SDK providers, whose Credentials wrapper
+// caches the value and retrieves it again once it expires.
+func baseCredentials(node nodes.Node) (*credentials.Credentials, error) {
+	switch node.Source() {
+	case nodes.CredentialStatic:
+		if node.AccessKey == "" || node.SecretKey == "" {
+			return nil, errors.New("v1: static credentials require an access key and a secret key")
+		}
+		return credentials.NewStaticCredentials(node.AccessKey, node.SecretKey, node.SessionToken), nil
+	case nodes.CredentialEnv:
+		return credentials.NewChainCredentials([]credentials.Provider{&credentials.EnvProvider{}}), nil
+	case nodes.CredentialProfile:
+		// Empty filename and profile fall back to the SDK defaults
+		// (AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials, AWS_PROFILE or "default")
+		return credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.SharedCredentialsProvider{Filename: node.CredentialsFile, Profile: node.Profile},
+		}), nil
+	case nodes.CredentialProcess:
+		if node.CredentialProcess == "" {
+			return nil, errors.New("v1: credential process source requires a command")
+		}
+		return processcreds.NewCredentials(node.CredentialProcess, func(p *processcreds.ProcessProvider) {
+			p.ExpiryWindow = processExpiryWindow
+		}), nil
+	default:
+		return nil, fmt.Errorf("v1: unknown credential source %q", node.CredentialSource)
+	}
+}
+
+// assumeRoleCredentials wraps base in an STS AssumeRole provider for node's role
+func assumeRoleCredentials(node nodes.Node, base *credentials.Credentials, httpClient *http.Client) (*credentials.Credentials, error) {
+	if node.RoleDuration != 0 && (node.RoleDuration < 900 || node.RoleDuration > 43200) {
+		return nil, fmt.Errorf("v1: role duration must be between 900 and 43200 seconds, got %d", node.RoleDuration)
+	}
+
+	stsCfg := &aws.Config{
+		Credentials: base,
+		Region:      aws.String(node.Region),
+		DisableSSL:  aws.Bool(node.DisableSSL),
+		HTTPClient:  httpClient,
+	}
+	if node.STSEndpoint != "" {
+		stsCfg.Endpoint = aws.String(node.STSEndpoint)
+	}
+	sess, err := session.NewSession(stsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("v1: unable to create STS session: %v", err)
+	}
+
+	sessionName := node.RoleSessionName
+	if sessionName == "" {
+		sessionName = defaultRoleSessionName
+	}
+	return stscreds.NewCredentials(sess, node.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = sessionName
+		if node.ExternalID != "" {
+			p.ExternalID = aws.String(node.ExternalID)
+		}
+		if node.RoleDuration > 0 {
+			p.Duration = time.Duration(node.RoleDuration) * time.Second
+		}
+		p.ExpiryWindow = processExpiryWindow
+	}), nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSTS is a local stand-in for the STS AssumeRole API. Each call issues a
+// new session whose lifetime is controlled by ttl.
+type fakeSTS struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	calls []map[string]string // form values of each AssumeRole request
+	auth  []string            // Authorization header of each request
+}
+
+func (f *fakeSTS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	form := make(map[string]string)
+	for k := range r.PostForm {
+		form[k] = r.PostForm.Get(k)
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, form)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	n := len(f.calls)
+	expiration := time.Now().Add(f.ttl).UTC().Format(time.RFC3339)
+	f.mu.Unlock()
+
+	if form["Action"] != "AssumeRole" {
+		http.Error(w, "unexpected action "+form["Action"], http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASIAROLE%d</AccessKeyId>
+      <SecretAccessKey>role-secret-%d</SecretAccessKey>
+      <SessionToken>role-token-%d</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <AssumedRoleId>AROAEXAMPLE:%s</AssumedRoleId>
+      <Arn>%s</Arn>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+  <ResponseMetadata><RequestId>req-%d</RequestId></ResponseMetadata>
+</AssumeRoleResponse>`, n, n, n, expiration, form["RoleSessionName"], form["RoleArn"], n)
+}
+
+func (f *fakeSTS) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func newFakeSTS(t *testing.T, ttl time.Duration) (*fakeSTS, *httptest.Server) {
+	t.Helper()
+	sts := &fakeSTS{ttl: ttl}
+	srv := httptest.NewServer(sts)
+	t.Cleanup(srv.Close)
+	return sts, srv
+}
+
+func roleNode(stsEndpoint string) nodes.Node {
+	return nodes.Node{
+		NodeName:        "prod",
+		AccessKey:       "AKIABASE",
+		SecretKey:       "base-secret",
+		Region:          "us-east-1",
+		RoleARN:         "arn:aws:iam::123456789012:role/prod-access",
+		ExternalID:      "ext-42",
+		RoleSessionName: "nightly-sync",
+		RoleDuration:    3600,
+		STSEndpoint:     stsEndpoint,
+	}
+}
+
+func TestAssumeRoleRequest(t *testing.T) {
+	sts, srv := newFakeSTS(t, time.Hour)
+
+	creds, err := newCredentials(roleNode(srv.URL), srv.Client())
+	if err != nil {
+		t.Fatalf("newCredentials: %v", err)
+	}
+	value, err := creds.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if value.AccessKeyID != "ASIAROLE1" || value.SecretAccessKey != "role-secret-1" || value.SessionToken != "role-token-1" {
+		t.Errorf("got credentials %+v, want the assumed role session", value)
+	}
+
+	if sts.callCount() != 1 {
+		t.Fatalf("STS called %d times, want 1", sts.callCount())
+	}
+	want := map[string]string{
+		"Action":          "AssumeRole",
+		"RoleArn":         "arn:aws:iam::123456789012:role/prod-access",
+		"RoleSessionName": "nightly-sync",
+		"ExternalId":      "ext-42",
+		"DurationSeconds": "3600",
+	}
+	for k, v := range want {
+		if got := sts.calls[0][k]; got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+	// The request is signed with the base identity
+	if auth := sts.auth[0]; !strings.Contains(auth, "Credential=AKIABASE/") {
+		t.Errorf("AssumeRole signed with %q, want the base access key", auth)
+	}
+}
+
+func TestAssumeRoleDefaults(t *testing.T) {
+	sts, srv := newFakeSTS(t, time.Hour)
+
+	node := roleNode(srv.URL)
+	node.ExternalID = ""
+	node.RoleSessionName = ""
+	node.RoleDuration = 0
+	creds, err := newCredentials(node, srv.Client())
+	if err != nil {
+		t.Fatalf("newCredentials: %v", err)
+	}
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	call := sts.calls[0]
+	if call["RoleSessionName"] != defaultRoleSessionName {
+		t.Errorf("RoleSessionName = %q, want %q", call["RoleSessionName"], defaultRoleSessionName)
+	}
+	if _, ok := call["ExternalId"]; ok {
+		t.Errorf("ExternalId sent without being configured: %q", call["ExternalId"])
+	}
+	// The SDK sends its own default duration when none is configured
+	if call["DurationSeconds"] != "900" {
+		t.Errorf("DurationSeconds = %q, want the 900 second default", call["DurationSeconds"])
+	}
+}
+
+func TestAssumeRoleCachesSession(t *testing.T) {
+	sts, srv := newFakeSTS(t, time.Hour)
+
+	creds, err := newCredentials(roleNode(srv.URL), srv.Client())
+	if err != nil {
+		t.Fatalf("newCredentials: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		value, err := creds.Get()
+		if err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+		if value.AccessKeyID != "ASIAROLE1" {
+			t.Errorf("Get #%d returned %s, want the cached session", i+1, value.AccessKeyID)
+		}
+	}
+	if sts.callCount() != 1 {
+		t.Errorf("STS called %d times, want the session to be cached", sts.callCount())
+	}
+}
+
+func TestAssumeRoleRefreshesBeforeExpiry(t *testing.T) {
+	// Sessions that expire inside the expiry window are renewed on the next use
+	sts, srv := newFakeSTS(t, processExpiryWindow/2)
+
+	creds, err := newCredentials(roleNode(srv.URL), srv.Client())
+	if err != nil {
+		t.Fatalf("newCredentials: %v", err)
+	}
+	first, err := creds.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	second, err := creds.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if first.AccessKeyID == second.AccessKeyID {
+		t.Errorf("session %s was reused although it is about to expire", first.AccessKeyID)
+	}
+	if sts.callCount() != 2 {
+		t.Errorf("STS called %d times, want 2", sts.callCount())
+	}
+
+	// Expire explicitly forces a new session as well
+	creds.Expire()
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("Get after Expire: %v", err)
+	}
+	if sts.callCount() != 3 {
+		t.Errorf("STS called %d times after Expire, want 3", sts.callCount())
+	}
+}
+
+func TestAssumeRoleDurationBounds(t *testing.T) {
+	for _, seconds := range []int{60, 899, 43201} {
+		node := roleNode("http://127.0.0.1:0")
+		node.RoleDuration = seconds
+		if _, err := newCredentials(node, http.DefaultClient); err == nil {
+			t.Errorf("RoleDuration %d accepted, want an error", seconds)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Package filelock 提供跨进程的建议性文件锁
+package filelock
+
+import (
+	"os"
+)
+
+// Lock 持有的文件锁
+type Lock struct {
+	f *os.File
+}
+
+// Acquire 阻塞直到获得 path 上的排他锁，锁文件不存在时会被创建
+func Acquire(path string) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Lock{f: f}, nil
+}
+
+// Release 释放锁
+func (l *Lock) Release() error {
+	err := unlockFile(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
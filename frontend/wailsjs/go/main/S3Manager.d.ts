@@ -1,20 +1,158 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {nodes} from '../models';
+import {accesslog} from '../models';
 import {main} from '../models';
+import {nodeio} from '../models';
 
-export function AddNode(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<boolean>;
+export function AddNode(arg1:nodes.Node):Promise<boolean>;
 
-export function DownloadObject(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string):Promise<void>;
+export function AnalyzeAccessLogs(arg1:string,arg2:string,arg3:string,arg4:number):Promise<accesslog.Report>;
+
+export function CancelNodeBucketInfo(arg1:string):Promise<void>;
+
+export function CreateBucket(arg1:string,arg2:nodes.CreateBucketOptions):Promise<void>;
+
+export function DeleteBucket(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function DeleteBucketCORS(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteBucketEncryption(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteBucketPolicy(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteBucketReplication(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteBucketTags(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteBucketWebsite(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteCORSRule(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function DeleteLifecycleRule(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DeleteNode(arg1:string):Promise<void>;
+
+export function DeleteObject(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<void>;
+
+export function DeleteObjectTags(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DeletePublicAccessBlock(arg1:string,arg2:string):Promise<void>;
+
+export function DeploySite(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<nodes.DeployResult>;
+
+export function DisableBucketLogging(arg1:string,arg2:string):Promise<void>;
+
+export function DisableVault(arg1:string):Promise<void>;
+
+export function DownloadObject(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function EnableBucketLogging(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function EnableVault(arg1:string):Promise<void>;
+
+export function EvaluateCORS(arg1:Array<nodes.CORSRule>,arg2:nodes.CORSRequest):Promise<nodes.CORSEvaluation>;
+
+export function ExportNodes(arg1:string,arg2:Array<string>):Promise<main.ExportResult>;
 
 export function GetAllS3NodesInfo():Promise<Array<nodes.Node>>;
 
-export function GetNodeBucketInfo(arg1:string,arg2:string,arg3:string,arg4:string):Promise<Array<nodes.NodeBucketInfo>>;
+export function GetBucketACL(arg1:string,arg2:string):Promise<nodes.BucketACL>;
+
+export function GetBucketCORS(arg1:string,arg2:string):Promise<Array<nodes.CORSRule>>;
+
+export function GetBucketEncryption(arg1:string,arg2:string):Promise<Array<nodes.EncryptionRule>>;
+
+export function GetBucketLifecycle(arg1:string,arg2:string):Promise<Array<nodes.LifecycleRule>>;
+
+export function GetBucketLogging(arg1:string,arg2:string):Promise<nodes.LoggingConfig>;
+
+export function GetBucketNotification(arg1:string,arg2:string):Promise<nodes.NotificationConfig>;
+
+export function GetBucketPolicy(arg1:string,arg2:string):Promise<string>;
+
+export function GetBucketReplication(arg1:string,arg2:string):Promise<nodes.ReplicationConfig>;
+
+export function GetBucketTags(arg1:string,arg2:string):Promise<Array<nodes.Tag>>;
+
+export function GetBucketVersioning(arg1:string,arg2:string):Promise<nodes.VersioningConfig>;
+
+export function GetBucketWebsite(arg1:string,arg2:string):Promise<nodes.WebsiteConfig>;
+
+export function GetNodeBucketInfo(arg1:string):Promise<Array<nodes.NodeBucketInfo>>;
+
+export function GetObjectInfo(arg1:string,arg2:string,arg3:string):Promise<main.ObjectInfo>;
+
+export function GetObjectLegalHold(arg1:string,arg2:string,arg3:string,arg4:string):Promise<boolean>;
+
+export function GetObjectLockConfig(arg1:string,arg2:string):Promise<nodes.ObjectLockConfig>;
+
+export function GetObjectRetention(arg1:string,arg2:string,arg3:string,arg4:string):Promise<nodes.ObjectRetention>;
+
+export function GetObjectTags(arg1:string,arg2:string,arg3:string):Promise<Array<nodes.Tag>>;
+
+export function GetPublicAccessBlock(arg1:string,arg2:string):Promise<nodes.PublicAccessBlock>;
+
+export function GetVaultStatus():Promise<main.VaultStatus>;
+
+export function ImportNodes(arg1:string,arg2:string):Promise<nodeio.Plan>;
+
+export function ListObjects(arg1:string,arg2:string,arg3:boolean):Promise<Array<main.ObjectInfo>>;
+
+export function LockVault():Promise<void>;
+
+export function MoveNode(arg1:string,arg2:number):Promise<void>;
+
+export function NewS3Client(arg1:string):Promise<main.S3Manager>;
+
+export function PreviewImport(arg1:string,arg2:string):Promise<nodeio.Plan>;
+
+export function PutBucketACL(arg1:string,arg2:string,arg3:nodes.BucketACL):Promise<void>;
+
+export function PutBucketCORS(arg1:string,arg2:string,arg3:Array<nodes.CORSRule>):Promise<void>;
+
+export function PutBucketNotification(arg1:string,arg2:string,arg3:nodes.NotificationConfig):Promise<void>;
+
+export function PutBucketPolicy(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function PutBucketReplication(arg1:string,arg2:string,arg3:nodes.ReplicationConfig):Promise<void>;
+
+export function PutBucketTags(arg1:string,arg2:string,arg3:Array<nodes.Tag>):Promise<void>;
+
+export function PutBucketWebsite(arg1:string,arg2:string,arg3:nodes.WebsiteConfig):Promise<void>;
+
+export function PutCORSRule(arg1:string,arg2:string,arg3:number,arg4:nodes.CORSRule):Promise<void>;
+
+export function PutLifecycleRule(arg1:string,arg2:string,arg3:nodes.LifecycleRule):Promise<void>;
+
+export function PutObjectLockConfig(arg1:string,arg2:string,arg3:nodes.ObjectLockConfig):Promise<void>;
+
+export function PutObjectRetention(arg1:string,arg2:string,arg3:string,arg4:string,arg5:nodes.ObjectRetention,arg6:boolean):Promise<void>;
+
+export function PutObjectTags(arg1:string,arg2:string,arg3:string,arg4:Array<nodes.Tag>):Promise<void>;
+
+export function PutPublicAccessBlock(arg1:string,arg2:string,arg3:nodes.PublicAccessBlock):Promise<void>;
+
+export function RenameNode(arg1:string,arg2:string):Promise<void>;
+
+export function SetBucketCannedACL(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetBucketEncryption(arg1:string,arg2:string,arg3:nodes.EncryptionRule):Promise<void>;
+
+export function SetBucketVersioning(arg1:string,arg2:string,arg3:nodes.VersioningConfig):Promise<void>;
+
+export function SetObjectLegalHold(arg1:string,arg2:string,arg3:string,arg4:string,arg5:boolean):Promise<void>;
+
+export function SetVaultAutoLock(arg1:number):Promise<void>;
+
+export function StreamNodeBucketInfo(arg1:string):Promise<void>;
+
+export function UnlockVault(arg1:string):Promise<void>;
 
-export function GetObjectInfo(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string):Promise<main.ObjectInfo>;
+export function UpdateNode(arg1:nodes.Node,arg2:boolean,arg3:boolean):Promise<void>;
 
-export function ListObjects(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<Array<main.ObjectInfo>>;
+export function UploadObject(arg1:string,arg2:string):Promise<string>;
 
-export function NewS3Client(arg1:string,arg2:string,arg3:string,arg4:string):Promise<main.S3Manager>;
+export function ValidateBucketNotification(arg1:nodes.NotificationConfig):Promise<Array<string>>;
 
-export function UploadObject(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<string>;
+export function ValidateBucketPolicy(arg1:string,arg2:string):Promise<Array<string>>;
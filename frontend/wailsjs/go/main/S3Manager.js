@@ -2,34 +2,306 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function AddNode(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['S3Manager']['AddNode'](arg1, arg2, arg3, arg4, arg5);
+export function AddNode(arg1) {
+  return window['go']['main']['S3Manager']['AddNode'](arg1);
 }
 
-export function DownloadObject(arg1, arg2, arg3, arg4, arg5, arg6) {
-  return window['go']['main']['S3Manager']['DownloadObject'](arg1, arg2, arg3, arg4, arg5, arg6);
+export function AnalyzeAccessLogs(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['AnalyzeAccessLogs'](arg1, arg2, arg3, arg4);
+}
+
+export function CancelNodeBucketInfo(arg1) {
+  return window['go']['main']['S3Manager']['CancelNodeBucketInfo'](arg1);
+}
+
+export function CreateBucket(arg1, arg2) {
+  return window['go']['main']['S3Manager']['CreateBucket'](arg1, arg2);
+}
+
+export function DeleteBucket(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['DeleteBucket'](arg1, arg2, arg3);
+}
+
+export function DeleteBucketCORS(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketCORS'](arg1, arg2);
+}
+
+export function DeleteBucketEncryption(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketEncryption'](arg1, arg2);
+}
+
+export function DeleteBucketPolicy(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketPolicy'](arg1, arg2);
+}
+
+export function DeleteBucketReplication(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketReplication'](arg1, arg2);
+}
+
+export function DeleteBucketTags(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketTags'](arg1, arg2);
+}
+
+export function DeleteBucketWebsite(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeleteBucketWebsite'](arg1, arg2);
+}
+
+export function DeleteCORSRule(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['DeleteCORSRule'](arg1, arg2, arg3);
+}
+
+export function DeleteLifecycleRule(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['DeleteLifecycleRule'](arg1, arg2, arg3);
+}
+
+export function DeleteNode(arg1) {
+  return window['go']['main']['S3Manager']['DeleteNode'](arg1);
+}
+
+export function DeleteObject(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['S3Manager']['DeleteObject'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function DeleteObjectTags(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['DeleteObjectTags'](arg1, arg2, arg3);
+}
+
+export function DeletePublicAccessBlock(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DeletePublicAccessBlock'](arg1, arg2);
+}
+
+export function DeploySite(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['DeploySite'](arg1, arg2, arg3, arg4);
+}
+
+export function DisableBucketLogging(arg1, arg2) {
+  return window['go']['main']['S3Manager']['DisableBucketLogging'](arg1, arg2);
+}
+
+export function DisableVault(arg1) {
+  return window['go']['main']['S3Manager']['DisableVault'](arg1);
+}
+
+export function DownloadObject(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['DownloadObject'](arg1, arg2, arg3);
+}
+
+export function EnableBucketLogging(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['EnableBucketLogging'](arg1, arg2, arg3, arg4);
+}
+
+export function EnableVault(arg1) {
+  return window['go']['main']['S3Manager']['EnableVault'](arg1);
+}
+
+export function EvaluateCORS(arg1, arg2) {
+  return window['go']['main']['S3Manager']['EvaluateCORS'](arg1, arg2);
+}
+
+export function ExportNodes(arg1, arg2) {
+  return window['go']['main']['S3Manager']['ExportNodes'](arg1, arg2);
 }
 
 export function GetAllS3NodesInfo() {
   return window['go']['main']['S3Manager']['GetAllS3NodesInfo']();
 }
 
-export function GetNodeBucketInfo(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['S3Manager']['GetNodeBucketInfo'](arg1, arg2, arg3, arg4);
+export function GetBucketACL(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketACL'](arg1, arg2);
+}
+
+export function GetBucketCORS(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketCORS'](arg1, arg2);
+}
+
+export function GetBucketEncryption(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketEncryption'](arg1, arg2);
+}
+
+export function GetBucketLifecycle(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketLifecycle'](arg1, arg2);
+}
+
+export function GetBucketLogging(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketLogging'](arg1, arg2);
+}
+
+export function GetBucketNotification(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketNotification'](arg1, arg2);
+}
+
+export function GetBucketPolicy(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketPolicy'](arg1, arg2);
+}
+
+export function GetBucketReplication(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketReplication'](arg1, arg2);
+}
+
+export function GetBucketTags(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketTags'](arg1, arg2);
+}
+
+export function GetBucketVersioning(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketVersioning'](arg1, arg2);
+}
+
+export function GetBucketWebsite(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetBucketWebsite'](arg1, arg2);
+}
+
+export function GetNodeBucketInfo(arg1) {
+  return window['go']['main']['S3Manager']['GetNodeBucketInfo'](arg1);
+}
+
+export function GetObjectInfo(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['GetObjectInfo'](arg1, arg2, arg3);
+}
+
+export function GetObjectLegalHold(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['GetObjectLegalHold'](arg1, arg2, arg3, arg4);
+}
+
+export function GetObjectLockConfig(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetObjectLockConfig'](arg1, arg2);
+}
+
+export function GetObjectRetention(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['GetObjectRetention'](arg1, arg2, arg3, arg4);
+}
+
+export function GetObjectTags(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['GetObjectTags'](arg1, arg2, arg3);
+}
+
+export function GetPublicAccessBlock(arg1, arg2) {
+  return window['go']['main']['S3Manager']['GetPublicAccessBlock'](arg1, arg2);
+}
+
+export function GetVaultStatus() {
+  return window['go']['main']['S3Manager']['GetVaultStatus']();
+}
+
+export function ImportNodes(arg1, arg2) {
+  return window['go']['main']['S3Manager']['ImportNodes'](arg1, arg2);
+}
+
+export function ListObjects(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['ListObjects'](arg1, arg2, arg3);
+}
+
+export function LockVault() {
+  return window['go']['main']['S3Manager']['LockVault']();
+}
+
+export function MoveNode(arg1, arg2) {
+  return window['go']['main']['S3Manager']['MoveNode'](arg1, arg2);
+}
+
+export function NewS3Client(arg1) {
+  return window['go']['main']['S3Manager']['NewS3Client'](arg1);
+}
+
+export function PreviewImport(arg1, arg2) {
+  return window['go']['main']['S3Manager']['PreviewImport'](arg1, arg2);
+}
+
+export function PutBucketACL(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketACL'](arg1, arg2, arg3);
+}
+
+export function PutBucketCORS(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketCORS'](arg1, arg2, arg3);
+}
+
+export function PutBucketNotification(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketNotification'](arg1, arg2, arg3);
+}
+
+export function PutBucketPolicy(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketPolicy'](arg1, arg2, arg3);
+}
+
+export function PutBucketReplication(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketReplication'](arg1, arg2, arg3);
+}
+
+export function PutBucketTags(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketTags'](arg1, arg2, arg3);
+}
+
+export function PutBucketWebsite(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutBucketWebsite'](arg1, arg2, arg3);
+}
+
+export function PutCORSRule(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['PutCORSRule'](arg1, arg2, arg3, arg4);
+}
+
+export function PutLifecycleRule(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutLifecycleRule'](arg1, arg2, arg3);
+}
+
+export function PutObjectLockConfig(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutObjectLockConfig'](arg1, arg2, arg3);
+}
+
+export function PutObjectRetention(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['S3Manager']['PutObjectRetention'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function PutObjectTags(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['S3Manager']['PutObjectTags'](arg1, arg2, arg3, arg4);
+}
+
+export function PutPublicAccessBlock(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['PutPublicAccessBlock'](arg1, arg2, arg3);
+}
+
+export function RenameNode(arg1, arg2) {
+  return window['go']['main']['S3Manager']['RenameNode'](arg1, arg2);
+}
+
+export function SetBucketCannedACL(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['SetBucketCannedACL'](arg1, arg2, arg3);
+}
+
+export function SetBucketEncryption(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['SetBucketEncryption'](arg1, arg2, arg3);
+}
+
+export function SetBucketVersioning(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['SetBucketVersioning'](arg1, arg2, arg3);
+}
+
+export function SetObjectLegalHold(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['S3Manager']['SetObjectLegalHold'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SetVaultAutoLock(arg1) {
+  return window['go']['main']['S3Manager']['SetVaultAutoLock'](arg1);
+}
+
+export function StreamNodeBucketInfo(arg1) {
+  return window['go']['main']['S3Manager']['StreamNodeBucketInfo'](arg1);
+}
+
+export function UnlockVault(arg1) {
+  return window['go']['main']['S3Manager']['UnlockVault'](arg1);
 }
 
-export function GetObjectInfo(arg1, arg2, arg3, arg4, arg5, arg6) {
-  return window['go']['main']['S3Manager']['GetObjectInfo'](arg1, arg2, arg3, arg4, arg5, arg6);
+export function UpdateNode(arg1, arg2, arg3) {
+  return window['go']['main']['S3Manager']['UpdateNode'](arg1, arg2, arg3);
 }
 
-export function ListObjects(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['S3Manager']['ListObjects'](arg1, arg2, arg3, arg4, arg5);
+export function UploadObject(arg1, arg2) {
+  return window['go']['main']['S3Manager']['UploadObject'](arg1, arg2);
 }
 
-export function NewS3Client(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['S3Manager']['NewS3Client'](arg1, arg2, arg3, arg4);
+export function ValidateBucketNotification(arg1) {
+  return window['go']['main']['S3Manager']['ValidateBucketNotification'](arg1);
 }
 
-export function UploadObject(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['S3Manager']['UploadObject'](arg1, arg2, arg3, arg4, arg5);
+export function ValidateBucketPolicy(arg1, arg2) {
+  return window['go']['main']['S3Manager']['ValidateBucketPolicy'](arg1, arg2);
 }
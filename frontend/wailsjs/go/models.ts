@@ -1,30 +1,630 @@
+export namespace accesslog {
+	
+	export class Count {
+	    name: string;
+	    requests: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Count(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.requests = source["requests"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+	export class Report {
+	    logObjects: number;
+	    failedLogs: number;
+	    lines: number;
+	    malformed: number;
+	    requests: number;
+	    errors: number;
+	    bytesSent: number;
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end: any;
+	    byOperation: Count[];
+	    byStatus: Count[];
+	    byKey: Count[];
+	    byRequester: Count[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.logObjects = source["logObjects"];
+	        this.failedLogs = source["failedLogs"];
+	        this.lines = source["lines"];
+	        this.malformed = source["malformed"];
+	        this.requests = source["requests"];
+	        this.errors = source["errors"];
+	        this.bytesSent = source["bytesSent"];
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.byOperation = this.convertValues(source["byOperation"], Count);
+	        this.byStatus = this.convertValues(source["byStatus"], Count);
+	        this.byKey = this.convertValues(source["byKey"], Count);
+	        this.byRequester = this.convertValues(source["byRequester"], Count);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace main {
 	
-	export class ObjectInfo {
-	    key: string;
-	    size: number;
-	    // Go type: time
-	    lastModified: any;
-	    etag: string;
-	    contentType: string;
+	export class ExportResult {
+	    dir: string;
+	    skipped: nodeio.Skipped[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dir = source["dir"];
+	        this.skipped = this.convertValues(source["skipped"], nodeio.Skipped);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ObjectInfo {
+	    key: string;
+	    size: number;
+	    // Go type: time
+	    lastModified: any;
+	    etag: string;
+	    contentType: string;
+	    storageClass: string;
+	    metadata: {[key: string]: string};
+	    versionId: string;
+	    tags: nodes.Tag[];
+	    lockMode: string;
+	    // Go type: time
+	    retainUntilDate?: any;
+	    legalHold: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ObjectInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.size = source["size"];
+	        this.lastModified = this.convertValues(source["lastModified"], null);
+	        this.etag = source["etag"];
+	        this.contentType = source["contentType"];
+	        this.storageClass = source["storageClass"];
+	        this.metadata = source["metadata"];
+	        this.versionId = source["versionId"];
+	        this.tags = this.convertValues(source["tags"], nodes.Tag);
+	        this.lockMode = source["lockMode"];
+	        this.retainUntilDate = this.convertValues(source["retainUntilDate"], null);
+	        this.legalHold = source["legalHold"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class S3Manager {
+	
+	
+	    static createFrom(source: any = {}) {
+	        return new S3Manager(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	
+	    }
+	}
+	export class VaultStatus {
+	    enabled: boolean;
+	    locked: boolean;
+	    autoLockMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VaultStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.locked = source["locked"];
+	        this.autoLockMinutes = source["autoLockMinutes"];
+	    }
+	}
+
+}
+
+export namespace nodeio {
+	
+	export class Skipped {
+	    node: nodes.Node;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Skipped(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.node = this.convertValues(source["node"], nodes.Node);
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Plan {
+	    add: nodes.Node[];
+	    skipped: Skipped[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Plan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.add = this.convertValues(source["add"], nodes.Node);
+	        this.skipped = this.convertValues(source["skipped"], Skipped);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace nodes {
+	
+	export class ACLGrant {
+	    granteeType: string;
+	    id: string;
+	    displayName: string;
+	    uri: string;
+	    email: string;
+	    permission: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ACLGrant(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.granteeType = source["granteeType"];
+	        this.id = source["id"];
+	        this.displayName = source["displayName"];
+	        this.uri = source["uri"];
+	        this.email = source["email"];
+	        this.permission = source["permission"];
+	    }
+	}
+	export class BucketACL {
+	    ownerId: string;
+	    ownerDisplayName: string;
+	    grants: ACLGrant[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BucketACL(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ownerId = source["ownerId"];
+	        this.ownerDisplayName = source["ownerDisplayName"];
+	        this.grants = this.convertValues(source["grants"], ACLGrant);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RoutingCondition {
+	    keyPrefixEquals: string;
+	    httpErrorCodeReturnedEquals: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutingCondition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keyPrefixEquals = source["keyPrefixEquals"];
+	        this.httpErrorCodeReturnedEquals = source["httpErrorCodeReturnedEquals"];
+	    }
+	}
+	export class RoutingRule {
+	    condition: RoutingCondition;
+	    redirect: WebsiteRedirect;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutingRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.condition = this.convertValues(source["condition"], RoutingCondition);
+	        this.redirect = this.convertValues(source["redirect"], WebsiteRedirect);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WebsiteRedirect {
+	    hostName: string;
+	    protocol: string;
+	    httpRedirectCode: string;
+	    replaceKeyPrefixWith: string;
+	    replaceKeyWith: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebsiteRedirect(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostName = source["hostName"];
+	        this.protocol = source["protocol"];
+	        this.httpRedirectCode = source["httpRedirectCode"];
+	        this.replaceKeyPrefixWith = source["replaceKeyPrefixWith"];
+	        this.replaceKeyWith = source["replaceKeyWith"];
+	    }
+	}
+	export class WebsiteConfig {
+	    enabled: boolean;
+	    indexDocument: string;
+	    errorDocument: string;
+	    redirectAllTo?: WebsiteRedirect;
+	    routingRules: RoutingRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WebsiteConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.indexDocument = source["indexDocument"];
+	        this.errorDocument = source["errorDocument"];
+	        this.redirectAllTo = this.convertValues(source["redirectAllTo"], WebsiteRedirect);
+	        this.routingRules = this.convertValues(source["routingRules"], RoutingRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LifecycleNoncurrentTransition {
+	    noncurrentDays: number;
+	    newerNoncurrentVersions: number;
+	    storageClass: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleNoncurrentTransition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.noncurrentDays = source["noncurrentDays"];
+	        this.newerNoncurrentVersions = source["newerNoncurrentVersions"];
+	        this.storageClass = source["storageClass"];
+	    }
+	}
+	export class LifecycleTransition {
+	    days: number;
+	    date: string;
+	    storageClass: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleTransition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.date = source["date"];
+	        this.storageClass = source["storageClass"];
+	    }
+	}
+	export class LifecycleNoncurrentExpiration {
+	    noncurrentDays: number;
+	    newerNoncurrentVersions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleNoncurrentExpiration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.noncurrentDays = source["noncurrentDays"];
+	        this.newerNoncurrentVersions = source["newerNoncurrentVersions"];
+	    }
+	}
+	export class LifecycleExpiration {
+	    days: number;
+	    date: string;
+	    expiredObjectDeleteMarker: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleExpiration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.date = source["date"];
+	        this.expiredObjectDeleteMarker = source["expiredObjectDeleteMarker"];
+	    }
+	}
+	export class LifecycleFilter {
+	    prefix: string;
+	    tags: Tag[];
+	    objectSizeGreaterThan: number;
+	    objectSizeLessThan: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.prefix = source["prefix"];
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.objectSizeGreaterThan = source["objectSizeGreaterThan"];
+	        this.objectSizeLessThan = source["objectSizeLessThan"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LifecycleRule {
+	    id: string;
+	    status: string;
+	    filter: LifecycleFilter;
+	    expiration?: LifecycleExpiration;
+	    noncurrentExpiration?: LifecycleNoncurrentExpiration;
+	    transitions: LifecycleTransition[];
+	    noncurrentTransitions: LifecycleNoncurrentTransition[];
+	    abortIncompleteMultipartDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.status = source["status"];
+	        this.filter = this.convertValues(source["filter"], LifecycleFilter);
+	        this.expiration = this.convertValues(source["expiration"], LifecycleExpiration);
+	        this.noncurrentExpiration = this.convertValues(source["noncurrentExpiration"], LifecycleNoncurrentExpiration);
+	        this.transitions = this.convertValues(source["transitions"], LifecycleTransition);
+	        this.noncurrentTransitions = this.convertValues(source["noncurrentTransitions"], LifecycleNoncurrentTransition);
+	        this.abortIncompleteMultipartDays = source["abortIncompleteMultipartDays"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EncryptionRule {
+	    algorithm: string;
+	    kmsKeyId: string;
+	    bucketKeyEnabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new EncryptionRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.algorithm = source["algorithm"];
+	        this.kmsKeyId = source["kmsKeyId"];
+	        this.bucketKeyEnabled = source["bucketKeyEnabled"];
+	    }
+	}
+	export class ReplicationRule {
+	    id: string;
+	    priority: number;
+	    status: string;
+	    prefix: string;
+	    tags: Tag[];
+	    destinationBucket: string;
 	    storageClass: string;
-	    metadata: {[key: string]: string};
-	    versionId: string;
+	    deleteMarkerReplication: boolean;
+	    destinationAccount: string;
+	    ownerOverride: boolean;
+	    replicaKmsKeyId: string;
+	    replicateKmsEncrypted: boolean;
+	    replicaModifications: boolean;
+	    existingObjects: boolean;
+	    replicationTimeMinutes: number;
+	    metrics: boolean;
+	    metricsThresholdMinutes: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ObjectInfo(source);
+	        return new ReplicationRule(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.key = source["key"];
-	        this.size = source["size"];
-	        this.lastModified = this.convertValues(source["lastModified"], null);
-	        this.etag = source["etag"];
-	        this.contentType = source["contentType"];
+	        this.id = source["id"];
+	        this.priority = source["priority"];
+	        this.status = source["status"];
+	        this.prefix = source["prefix"];
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.destinationBucket = source["destinationBucket"];
 	        this.storageClass = source["storageClass"];
-	        this.metadata = source["metadata"];
-	        this.versionId = source["versionId"];
+	        this.deleteMarkerReplication = source["deleteMarkerReplication"];
+	        this.destinationAccount = source["destinationAccount"];
+	        this.ownerOverride = source["ownerOverride"];
+	        this.replicaKmsKeyId = source["replicaKmsKeyId"];
+	        this.replicateKmsEncrypted = source["replicateKmsEncrypted"];
+	        this.replicaModifications = source["replicaModifications"];
+	        this.existingObjects = source["existingObjects"];
+	        this.replicationTimeMinutes = source["replicationTimeMinutes"];
+	        this.metrics = source["metrics"];
+	        this.metricsThresholdMinutes = source["metricsThresholdMinutes"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -45,38 +645,139 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class S3Manager {
+	export class ReplicationConfig {
+	    role: string;
+	    rules: ReplicationRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReplicationConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.rules = this.convertValues(source["rules"], ReplicationRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ObjectLockConfig {
+	    enabled: boolean;
+	    mode: string;
+	    days: number;
+	    years: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ObjectLockConfig(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.mode = source["mode"];
+	        this.days = source["days"];
+	        this.years = source["years"];
+	    }
+	}
+	export class Tag {
+	    key: string;
+	    value: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new S3Manager(source);
+	        return new Tag(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.value = source["value"];
+	    }
+	}
+	export class CORSRule {
+	    id: string;
+	    allowedOrigins: string[];
+	    allowedMethods: string[];
+	    allowedHeaders: string[];
+	    exposeHeaders: string[];
+	    maxAgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CORSRule(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.allowedOrigins = source["allowedOrigins"];
+	        this.allowedMethods = source["allowedMethods"];
+	        this.allowedHeaders = source["allowedHeaders"];
+	        this.exposeHeaders = source["exposeHeaders"];
+	        this.maxAgeSeconds = source["maxAgeSeconds"];
 	    }
 	}
-
-}
-
-export namespace nodes {
+	export class PublicAccessBlock {
+	    blockPublicAcls: boolean;
+	    ignorePublicAcls: boolean;
+	    blockPublicPolicy: boolean;
+	    restrictPublicBuckets: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PublicAccessBlock(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.blockPublicAcls = source["blockPublicAcls"];
+	        this.ignorePublicAcls = source["ignorePublicAcls"];
+	        this.blockPublicPolicy = source["blockPublicPolicy"];
+	        this.restrictPublicBuckets = source["restrictPublicBuckets"];
+	    }
+	}
 	export class BucketInfo {
 	    name: string;
 	    // Go type: time
 	    creationDate: any;
 	    usedSpace: number;
 	    totalObjects: number;
+	    sizeIncomplete: boolean;
 	    versioningEnabled: boolean;
+	    versioningStatus: string;
+	    mfaDelete: string;
 	    publicAccessBlocked: boolean;
+	    publicAccessBlock: PublicAccessBlock;
+	    aclGrants: ACLGrant[];
+	    corsRules: CORSRule[];
+	    tags: Tag[];
+	    objectLock: ObjectLockConfig;
+	    replicationEnabled: boolean;
+	    replication: ReplicationConfig;
 	    hasPolicy: boolean;
 	    encryptionEnabled: boolean;
 	    encryptionType: string;
+	    encryptionRules: EncryptionRule[];
+	    encryptionError: string;
 	    hasLifecycleRules: boolean;
 	    lifecycleRulesCount: number;
+	    lifecycleRules: LifecycleRule[];
 	    region: string;
 	    websiteEnabled: boolean;
+	    website: WebsiteConfig;
 	
 	    static createFrom(source: any = {}) {
 	        return new BucketInfo(source);
@@ -88,15 +789,29 @@ export namespace nodes {
 	        this.creationDate = this.convertValues(source["creationDate"], null);
 	        this.usedSpace = source["usedSpace"];
 	        this.totalObjects = source["totalObjects"];
+	        this.sizeIncomplete = source["sizeIncomplete"];
 	        this.versioningEnabled = source["versioningEnabled"];
+	        this.versioningStatus = source["versioningStatus"];
+	        this.mfaDelete = source["mfaDelete"];
 	        this.publicAccessBlocked = source["publicAccessBlocked"];
+	        this.publicAccessBlock = this.convertValues(source["publicAccessBlock"], PublicAccessBlock);
+	        this.aclGrants = this.convertValues(source["aclGrants"], ACLGrant);
+	        this.corsRules = this.convertValues(source["corsRules"], CORSRule);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	        this.objectLock = this.convertValues(source["objectLock"], ObjectLockConfig);
+	        this.replicationEnabled = source["replicationEnabled"];
+	        this.replication = this.convertValues(source["replication"], ReplicationConfig);
 	        this.hasPolicy = source["hasPolicy"];
 	        this.encryptionEnabled = source["encryptionEnabled"];
 	        this.encryptionType = source["encryptionType"];
+	        this.encryptionRules = this.convertValues(source["encryptionRules"], EncryptionRule);
+	        this.encryptionError = source["encryptionError"];
 	        this.hasLifecycleRules = source["hasLifecycleRules"];
 	        this.lifecycleRulesCount = source["lifecycleRulesCount"];
+	        this.lifecycleRules = this.convertValues(source["lifecycleRules"], LifecycleRule);
 	        this.region = source["region"];
 	        this.websiteEnabled = source["websiteEnabled"];
+	        this.website = this.convertValues(source["website"], WebsiteConfig);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -117,12 +832,128 @@ export namespace nodes {
 		    return a;
 		}
 	}
+	export class CORSEvaluation {
+	    allowed: boolean;
+	    matchedRule: number;
+	    reasons: string[];
+	    responseHeaders: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new CORSEvaluation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.allowed = source["allowed"];
+	        this.matchedRule = source["matchedRule"];
+	        this.reasons = source["reasons"];
+	        this.responseHeaders = source["responseHeaders"];
+	    }
+	}
+	export class CORSRequest {
+	    origin: string;
+	    method: string;
+	    headers: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CORSRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.origin = source["origin"];
+	        this.method = source["method"];
+	        this.headers = source["headers"];
+	    }
+	}
+	
+	export class CreateBucketOptions {
+	    name: string;
+	    region: string;
+	    objectLock: boolean;
+	    versioning: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateBucketOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.region = source["region"];
+	        this.objectLock = source["objectLock"];
+	        this.versioning = source["versioning"];
+	    }
+	}
+	export class DeployResult {
+	    endpoint: string;
+	    uploaded: number;
+	    unchanged: number;
+	    deleted: number;
+	    stale: string[];
+	    failed: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DeployResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint = source["endpoint"];
+	        this.uploaded = source["uploaded"];
+	        this.unchanged = source["unchanged"];
+	        this.deleted = source["deleted"];
+	        this.stale = source["stale"];
+	        this.failed = source["failed"];
+	    }
+	}
+	
+	
+	
+	
+	
+	
+	
+	export class LoggingConfig {
+	    enabled: boolean;
+	    targetBucket: string;
+	    targetPrefix: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoggingConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.targetBucket = source["targetBucket"];
+	        this.targetPrefix = source["targetPrefix"];
+	    }
+	}
 	export class Node {
+	    ID: string;
 	    NodeName: string;
 	    EndPoint: string;
 	    AccessKey: string;
 	    SecretKey: string;
 	    Region: string;
+	    AddressingStyle?: string;
+	    DisableSSL?: boolean;
+	    CABundle?: string;
+	    SkipTLSVerify?: boolean;
+	    Proxy?: string;
+	    ConnectTimeout?: number;
+	    ReadTimeout?: number;
+	    CredentialSource?: string;
+	    SessionToken?: string;
+	    Profile?: string;
+	    CredentialsFile?: string;
+	    CredentialProcess?: string;
+	    RoleARN?: string;
+	    ExternalID?: string;
+	    RoleSessionName?: string;
+	    RoleDuration?: number;
+	    STSEndpoint?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Node(source);
@@ -130,17 +961,36 @@ export namespace nodes {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
 	        this.NodeName = source["NodeName"];
 	        this.EndPoint = source["EndPoint"];
 	        this.AccessKey = source["AccessKey"];
 	        this.SecretKey = source["SecretKey"];
 	        this.Region = source["Region"];
+	        this.AddressingStyle = source["AddressingStyle"];
+	        this.DisableSSL = source["DisableSSL"];
+	        this.CABundle = source["CABundle"];
+	        this.SkipTLSVerify = source["SkipTLSVerify"];
+	        this.Proxy = source["Proxy"];
+	        this.ConnectTimeout = source["ConnectTimeout"];
+	        this.ReadTimeout = source["ReadTimeout"];
+	        this.CredentialSource = source["CredentialSource"];
+	        this.SessionToken = source["SessionToken"];
+	        this.Profile = source["Profile"];
+	        this.CredentialsFile = source["CredentialsFile"];
+	        this.CredentialProcess = source["CredentialProcess"];
+	        this.RoleARN = source["RoleARN"];
+	        this.ExternalID = source["ExternalID"];
+	        this.RoleSessionName = source["RoleSessionName"];
+	        this.RoleDuration = source["RoleDuration"];
+	        this.STSEndpoint = source["STSEndpoint"];
 	    }
 	}
 	export class NodeBucketInfo {
 	    nodeName: string;
 	    endPoint: string;
 	    buckets: BucketInfo[];
+	    cancelled: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new NodeBucketInfo(source);
@@ -151,6 +1001,96 @@ export namespace nodes {
 	        this.nodeName = source["nodeName"];
 	        this.endPoint = source["endPoint"];
 	        this.buckets = this.convertValues(source["buckets"], BucketInfo);
+	        this.cancelled = source["cancelled"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NotificationRule {
+	    id: string;
+	    type: string;
+	    targetArn: string;
+	    events: string[];
+	    prefix: string;
+	    suffix: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.targetArn = source["targetArn"];
+	        this.events = source["events"];
+	        this.prefix = source["prefix"];
+	        this.suffix = source["suffix"];
+	    }
+	}
+	export class NotificationConfig {
+	    rules: NotificationRule[];
+	    eventBridge: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rules = this.convertValues(source["rules"], NotificationRule);
+	        this.eventBridge = source["eventBridge"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ObjectRetention {
+	    mode: string;
+	    // Go type: time
+	    retainUntilDate: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ObjectRetention(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.retainUntilDate = this.convertValues(source["retainUntilDate"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -171,6 +1111,29 @@ export namespace nodes {
 		    return a;
 		}
 	}
+	
+	
+	
+	
+	
+	
+	export class VersioningConfig {
+	    status: string;
+	    mfaDelete: string;
+	    mfa: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersioningConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.mfaDelete = source["mfaDelete"];
+	        this.mfa = source["mfa"];
+	    }
+	}
+	
 
 }
 
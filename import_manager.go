@@ -0,0 +1,171 @@
+package main
+
+import (
+	"SRSC-Client/nodeio"
+	nodes "SRSC-Client/type"
+	file "SRSC-Client/utils"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// readImportSource 读取待导入的配置文件，path 为空时使用该格式的默认位置。
+// AWS 格式的 path 指向 config 文件，credentials 文件取同目录下的 credentials
+func readImportSource(format, path string) (nodeio.Source, error) {
+	var paths []string
+	if path == "" {
+		var err error
+		if paths, err = nodeio.DefaultPaths(format); err != nil {
+			return nodeio.Source{}, err
+		}
+	} else {
+		paths = []string{path}
+		if format == nodeio.FormatAWS {
+			paths = append(paths, filepath.Join(filepath.Dir(path), "credentials"))
+		}
+	}
+
+	var src nodeio.Source
+	content, err := os.ReadFile(paths[0])
+	if err != nil {
+		return src, fmt.Errorf("unable to read %s: %v", paths[0], err)
+	}
+	src.Config = content
+	if len(paths) > 1 {
+		// credentials 文件可以不存在
+		if content, err := os.ReadFile(paths[1]); err == nil {
+			src.Credentials = content
+		} else if !os.IsNotExist(err) {
+			return src, fmt.Errorf("unable to read %s: %v", paths[1], err)
+		}
+	}
+	return src, nil
+}
+
+// planImport 解析配置文件并与已有节点对比，生成导入计划
+func planImport(existing []nodes.Node, format, path string) (nodeio.Plan, error) {
+	src, err := readImportSource(format, path)
+	if err != nil {
+		return nodeio.Plan{}, err
+	}
+	imported, unsupported, err := nodeio.Parse(format, src)
+	if err != nil {
+		return nodeio.Plan{}, err
+	}
+	plan := nodeio.PlanImport(existing, imported)
+	plan.Skipped = append(plan.Skipped, unsupported...)
+	return plan, nil
+}
+
+// redactPlan 去掉计划中的密钥后返回给前端
+func redactPlan(plan nodeio.Plan) nodeio.Plan {
+	for i := range plan.Add {
+		plan.Add[i] = plan.Add[i].Redacted()
+	}
+	plan.Skipped = redactSkipped(plan.Skipped)
+	return plan
+}
+
+// redactSkipped 去掉跳过列表中的密钥
+func redactSkipped(skipped []nodeio.Skipped) []nodeio.Skipped {
+	for i := range skipped {
+		skipped[i].Node = skipped[i].Node.Redacted()
+	}
+	return skipped
+}
+
+// PreviewImport 预览从 rclone/s3cmd/mc/aws 配置导入时将添加和跳过的节点，不修改配置
+func (a *S3Manager) PreviewImport(format, path string) (nodeio.Plan, error) {
+	existing, err := loadNodes()
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to load node list: "+err.Error())
+		return nodeio.Plan{}, err
+	}
+	plan, err := planImport(existing, format, path)
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to preview import: "+err.Error())
+		return nodeio.Plan{}, err
+	}
+	return redactPlan(plan), nil
+}
+
+// ImportNodes 从 rclone/s3cmd/mc/aws 配置导入节点，跳过端点和 AccessKey 都相同的重复节点
+func (a *S3Manager) ImportNodes(format, path string) (nodeio.Plan, error) {
+	var plan nodeio.Plan
+	err := editNodes("import nodes", func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		var err error
+		if plan, err = planImport(nodeList, format, path); err != nil {
+			return nil, err
+		}
+		for i := range plan.Add {
+			plan.Add[i].ID = nodes.NewID()
+			nodeList = nodes.AddNode(nodeList, plan.Add[i])
+		}
+		return nodeList, nil
+	})
+	if err != nil {
+		return nodeio.Plan{}, err
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("Imported %d nodes, skipped %d", len(plan.Add), len(plan.Skipped)))
+	return redactPlan(plan), nil
+}
+
+// ExportResult 导出结果：写入的目录和因凭证来源无法在该格式中表示而跳过的节点
+type ExportResult struct {
+	Dir     string           `json:"dir"`
+	Skipped []nodeio.Skipped `json:"skipped"`
+}
+
+// ExportNodes 将指定节点(为空时导出全部)导出为 rclone/s3cmd/mc/aws 配置文件，
+// 写入用户选择的目录，返回该目录和被跳过的节点。导出的文件包含明文密钥，不会覆盖已有文件
+func (a *S3Manager) ExportNodes(format string, nodeIDs []string) (ExportResult, error) {
+	nodeList, err := loadNodes()
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to load node list: "+err.Error())
+		return ExportResult{}, err
+	}
+	if len(nodeIDs) > 0 {
+		var selected []nodes.Node
+		for _, id := range nodeIDs {
+			i := nodes.FindNode(nodeList, id)
+			if i < 0 {
+				return ExportResult{}, nodes.ErrNodeNotFound
+			}
+			selected = append(selected, nodeList[i])
+		}
+		nodeList = selected
+	}
+	if len(nodeList) == 0 {
+		return ExportResult{}, errors.New("no nodes to export")
+	}
+
+	files, skipped, err := nodeio.Export(format, nodeList)
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to export nodes: "+err.Error())
+		return ExportResult{}, err
+	}
+	for _, s := range skipped {
+		runtime.LogWarning(ContextX, fmt.Sprintf("Skipped node %q: %s", s.Node.NodeName, s.Reason))
+	}
+
+	dir := file.GetDirPath(ContextX)
+	if dir == "" {
+		return ExportResult{}, errors.New("export directory selection cancelled")
+	}
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return ExportResult{}, fmt.Errorf("%s already exists in %s", name, dir)
+		}
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			runtime.LogError(ContextX, "Failed to write export file: "+err.Error())
+			return ExportResult{}, fmt.Errorf("unable to write %s: %v", name, err)
+		}
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("Exported %d nodes as %s to %s", len(nodeList)-len(skipped), format, dir))
+	return ExportResult{Dir: dir, Skipped: redactSkipped(skipped)}, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"flag"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -13,6 +14,9 @@ import (
 var assets embed.FS
 
 func main() {
+	flag.StringVar(&configOverride, "config", "", "path to config.json (overrides "+configEnv+")")
+	flag.Parse()
+
 	// Create an instance of the app structure
 	app := NewApp()
 	// Create node manager instance
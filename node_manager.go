@@ -0,0 +1,68 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// editNodes 在持有配置锁期间读取节点列表，交给 edit 修改后写回配置文件
+func editNodes(action string, edit func([]nodes.Node) ([]nodes.Node, error)) error {
+	if err := updateNodes(edit); err != nil {
+		runtime.LogError(ContextX, "Failed to "+action+": "+err.Error())
+		return err
+	}
+	runtime.LogDebug(ContextX, "Node list saved after "+action)
+	return nil
+}
+
+// UpdateNode 按 ID 更新节点的全部设置，SecretKey、SessionToken 为空时保留原值。
+// 要删除已保存的值(如会话令牌过期后改回长期密钥)，将 clearSecretKey、clearSessionToken 置为 true
+func (a *S3Manager) UpdateNode(node nodes.Node, clearSecretKey, clearSessionToken bool) error {
+	node.NodeName = strings.TrimSpace(node.NodeName)
+	return editNodes("update node", func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		i := nodes.FindNode(nodeList, node.ID)
+		if i < 0 {
+			return nil, nodes.ErrNodeNotFound
+		}
+		old := nodeList[i]
+		if node.SecretKey == "" && !clearSecretKey {
+			node.SecretKey = old.SecretKey
+		}
+		if node.SessionToken == "" && !clearSessionToken {
+			node.SessionToken = old.SessionToken
+		}
+		nodeList, err := nodes.UpdateNode(nodeList, node)
+		if err == nil {
+			s3Clients.Forget(old)
+		}
+		return nodeList, err
+	})
+}
+
+// DeleteNode 删除指定节点
+func (a *S3Manager) DeleteNode(id string) error {
+	return editNodes("delete node", func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		i := nodes.FindNode(nodeList, id)
+		if i < 0 {
+			return nil, nodes.ErrNodeNotFound
+		}
+		s3Clients.Forget(nodeList[i])
+		return nodes.DeleteNode(nodeList, id)
+	})
+}
+
+// RenameNode 修改节点名称
+func (a *S3Manager) RenameNode(id, name string) error {
+	return editNodes("rename node", func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		return nodes.RenameNode(nodeList, id, name)
+	})
+}
+
+// MoveNode 将节点移动到列表中的 index 位置
+func (a *S3Manager) MoveNode(id string, index int) error {
+	return editNodes("move node", func(nodeList []nodes.Node) ([]nodes.Node, error) {
+		return nodes.MoveNode(nodeList, id, index)
+	})
+}
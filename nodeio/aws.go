@@ -0,0 +1,185 @@
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultRegion 配置中未指定区域时使用
+const defaultRegion = "us-east-1"
+
+// awsEndpoint 返回 AWS S3 的区域端点
+func awsEndpoint(region string) string {
+	if region == "" {
+		region = defaultRegion
+	}
+	return "https://s3." + region + ".amazonaws.com"
+}
+
+// ParseAWS 解析 AWS CLI 的 config 和 credentials 文件，每个 profile 生成一个节点。
+// 有静态密钥的 profile 直接复制密钥；使用 credential_process 的保留命令；
+// 其余 profile 以 profile 方式引用；role_arn 使用 source_profile 的密钥作为基础身份。
+// 基础身份来自 EC2 实例元数据、ECS 容器等无法在节点中表示的 profile 放入 unsupported
+func ParseAWS(config, credentials []byte) (result []nodes.Node, unsupported []Skipped, err error) {
+	profiles := make(map[string]map[string]string)
+	merge := func(name string, keys map[string]string) {
+		if profiles[name] == nil {
+			profiles[name] = make(map[string]string)
+		}
+		for k, v := range keys {
+			profiles[name][k] = v
+		}
+	}
+	for _, section := range parseINI(config) {
+		name := section.Name
+		if name != "default" {
+			var ok bool
+			if name, ok = strings.CutPrefix(name, "profile "); !ok {
+				continue // sso-session 等其他节
+			}
+			name = strings.TrimSpace(name)
+		}
+		merge(name, section.Keys)
+	}
+	for _, section := range parseINI(credentials) {
+		merge(section.Name, section.Keys)
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := profiles[name]
+		node := nodes.Node{
+			NodeName: "aws-" + name,
+			Region:   p["region"],
+		}
+		if node.Region == "" {
+			node.Region = defaultRegion
+		}
+
+		node.EndPoint = p["s3.endpoint_url"]
+		if node.EndPoint == "" {
+			node.EndPoint = p["endpoint_url"]
+		}
+		switch p["s3.addressing_style"] {
+		case "path":
+			node.AddressingStyle = nodes.AddressingPath
+		case "virtual":
+			node.AddressingStyle = nodes.AddressingVirtual
+		default:
+			if node.EndPoint == "" {
+				node.AddressingStyle = nodes.AddressingVirtual
+			}
+		}
+		if node.EndPoint == "" {
+			node.EndPoint = awsEndpoint(node.Region)
+		}
+
+		creds := p
+		if p["role_arn"] != "" {
+			node.RoleARN = p["role_arn"]
+			node.ExternalID = p["external_id"]
+			node.RoleSessionName = p["role_session_name"]
+			node.RoleDuration, _ = strconv.Atoi(p["duration_seconds"])
+			if source := profiles[p["source_profile"]]; source != nil {
+				creds = source
+			} else if reason := unsupportedRoleSource(p); reason != "" {
+				unsupported = append(unsupported, Skipped{Node: node, Reason: reason})
+				continue
+			}
+			if p["credential_source"] == "Environment" {
+				node.CredentialSource = nodes.CredentialEnv
+			}
+		}
+
+		switch {
+		case node.CredentialSource == nodes.CredentialEnv:
+		case creds["aws_access_key_id"] != "":
+			node.AccessKey = creds["aws_access_key_id"]
+			node.SecretKey = creds["aws_secret_access_key"]
+			node.SessionToken = creds["aws_session_token"]
+		case creds["credential_process"] != "":
+			node.CredentialSource = nodes.CredentialProcess
+			node.CredentialProcess = creds["credential_process"]
+		default:
+			// 扮演角色时引用基础身份所在的 profile，角色由节点自己的 RoleARN 扮演
+			node.CredentialSource = nodes.CredentialProfile
+			node.Profile = name
+			if node.RoleARN != "" && p["source_profile"] != "" {
+				node.Profile = p["source_profile"]
+			}
+		}
+		result = append(result, node)
+	}
+	return result, unsupported, nil
+}
+
+// unsupportedRoleSource 返回没有可用 source_profile 的角色 profile 无法导入的原因，
+// 仅 credential_source = Environment 可以用 env 凭证来源表示
+func unsupportedRoleSource(p map[string]string) string {
+	switch source := p["credential_source"]; source {
+	case "Environment":
+		return ""
+	case "":
+		if p["source_profile"] != "" {
+			return fmt.Sprintf("source_profile %q not found", p["source_profile"])
+		}
+		return "role_arn without source_profile or credential_source"
+	default:
+		return fmt.Sprintf("credential_source %s is not supported", source)
+	}
+}
+
+// ExportAWS 将节点导出为 AWS CLI 的 config 和 credentials 文件内容，profile 名取自节点名
+func ExportAWS(nodeList []nodes.Node) (config, credentials []byte) {
+	cw := &iniWriter{}
+	kw := &iniWriter{}
+	names := exportNames(nodeList)
+	for i, node := range nodeList {
+		name := names[i]
+		cw.Section("profile " + name)
+		cw.Key("region", node.Region)
+		cw.Key("endpoint_url", endpointURL(node.EndPoint, node.DisableSSL))
+		cw.Key("role_arn", node.RoleARN)
+		cw.Key("external_id", node.ExternalID)
+		cw.Key("role_session_name", node.RoleSessionName)
+		if node.RoleDuration > 0 {
+			cw.Key("duration_seconds", strconv.Itoa(node.RoleDuration))
+		}
+
+		switch node.Source() {
+		case nodes.CredentialStatic:
+			kw.Section(name)
+			kw.Key("aws_access_key_id", node.AccessKey)
+			kw.Key("aws_secret_access_key", node.SecretKey)
+			kw.Key("aws_session_token", node.SessionToken)
+			if node.RoleARN != "" {
+				cw.Key("source_profile", name)
+			}
+		case nodes.CredentialProcess:
+			cw.Key("credential_process", node.CredentialProcess)
+		case nodes.CredentialEnv:
+			if node.RoleARN != "" {
+				cw.Key("credential_source", "Environment")
+			}
+		case nodes.CredentialProfile:
+			if node.RoleARN != "" && node.Profile != "" {
+				cw.Key("source_profile", node.Profile)
+			}
+		}
+
+		style := nodes.AddressingPath
+		if !node.UsePathStyle() {
+			style = nodes.AddressingVirtual
+		}
+		cw.SubKey("s3", "addressing_style", style)
+	}
+	return cw.Bytes(), kw.Bytes()
+}
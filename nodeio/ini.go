@@ -0,0 +1,98 @@
+package nodeio
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// iniSection INI 文件中的一节，保留键的出现顺序
+type iniSection struct {
+	Name string
+	Keys map[string]string
+}
+
+// Get 返回键值，不存在时返回空串
+func (s *iniSection) Get(key string) string {
+	return s.Keys[key]
+}
+
+// parseINI 解析 rclone、s3cmd 和 AWS CLI 使用的 INI 格式。
+// AWS CLI 的嵌套写法(如 "s3 =" 后跟缩进的 "endpoint_url = ...")会展开为 "s3.endpoint_url"
+func parseINI(data []byte) []*iniSection {
+	var sections []*iniSection
+	var current *iniSection
+	parent := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = &iniSection{
+				Name: strings.TrimSpace(line[1 : len(line)-1]),
+				Keys: make(map[string]string),
+			}
+			sections = append(sections, current)
+			parent = ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		indented := raw != strings.TrimLeft(raw, " \t")
+		if indented && parent != "" {
+			current.Keys[parent+"."+key] = value
+			continue
+		}
+		current.Keys[key] = value
+		parent = ""
+		if value == "" {
+			parent = key
+		}
+	}
+	return sections
+}
+
+// iniWriter 按顺序生成 INI 文本
+type iniWriter struct {
+	buf bytes.Buffer
+}
+
+// Section 开始新的一节
+func (w *iniWriter) Section(name string) {
+	if w.buf.Len() > 0 {
+		w.buf.WriteString("\n")
+	}
+	w.buf.WriteString("[" + name + "]\n")
+}
+
+// Key 写入键值，值为空时跳过
+func (w *iniWriter) Key(key, value string) {
+	if value == "" {
+		return
+	}
+	w.buf.WriteString(key + " = " + value + "\n")
+}
+
+// SubKey 写入 AWS 配置风格的嵌套键值(如 s3 下的 addressing_style)，值为空时跳过
+func (w *iniWriter) SubKey(parent, key, value string) {
+	if value == "" {
+		return
+	}
+	w.buf.WriteString(parent + " =\n  " + key + " = " + value + "\n")
+}
+
+// Bytes 返回生成的文本
+func (w *iniWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
@@ -0,0 +1,87 @@
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// mcAlias MinIO mc 配置中的一个别名
+type mcAlias struct {
+	URL       string `json:"url"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	API       string `json:"api"`
+	Path      string `json:"path"`
+}
+
+// mcConfig ~/.mc/config.json，版本 9 及以前使用 hosts，之后使用 aliases
+type mcConfig struct {
+	Version string             `json:"version"`
+	Aliases map[string]mcAlias `json:"aliases,omitempty"`
+	Hosts   map[string]mcAlias `json:"hosts,omitempty"`
+}
+
+// ParseMC 解析 MinIO mc 的 config.json，跳过没有填写密钥的默认别名
+func ParseMC(data []byte) ([]nodes.Node, error) {
+	var cfg mcConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse mc config: %v", err)
+	}
+	aliases := cfg.Aliases
+	if aliases == nil {
+		aliases = cfg.Hosts
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []nodes.Node
+	for _, name := range names {
+		alias := aliases[name]
+		if alias.URL == "" || alias.AccessKey == "" || strings.HasPrefix(alias.AccessKey, "YOUR-") {
+			continue
+		}
+		node := nodes.Node{
+			NodeName:  name,
+			EndPoint:  alias.URL,
+			AccessKey: alias.AccessKey,
+			SecretKey: alias.SecretKey,
+			Region:    defaultRegion,
+		}
+		if alias.Path == "off" {
+			node.AddressingStyle = nodes.AddressingVirtual
+		}
+		if _, isHTTP := hostOf(alias.URL); isHTTP {
+			node.DisableSSL = true
+		}
+		result = append(result, node)
+	}
+	return result, nil
+}
+
+// ExportMC 将节点导出为 mc 的 config.json。mc 只能保存静态密钥，其他凭证来源的节点被跳过并返回
+func ExportMC(nodeList []nodes.Node) ([]byte, []Skipped, error) {
+	nodeList, skipped := exportable(FormatMC, nodeList, nodes.CredentialStatic)
+	cfg := mcConfig{Version: "10", Aliases: make(map[string]mcAlias)}
+	names := exportNames(nodeList)
+	for i, node := range nodeList {
+		path := "on"
+		if !node.UsePathStyle() {
+			path = "off"
+		}
+		cfg.Aliases[names[i]] = mcAlias{
+			URL:       endpointURL(node.EndPoint, node.DisableSSL),
+			AccessKey: node.AccessKey,
+			SecretKey: node.SecretKey,
+			API:       "s3v4",
+			Path:      path,
+		}
+	}
+	content, err := json.MarshalIndent(cfg, "", "\t")
+	return content, skipped, err
+}
@@ -0,0 +1,271 @@
+// Package nodeio 在节点列表与 rclone、s3cmd、MinIO mc、AWS CLI 的配置文件之间导入导出
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// 支持的配置格式
+const (
+	FormatRclone = "rclone" // rclone.conf
+	FormatS3cmd  = "s3cmd"  // ~/.s3cfg
+	FormatMC     = "mc"     // ~/.mc/config.json
+	FormatAWS    = "aws"    // ~/.aws/config + ~/.aws/credentials
+)
+
+// Source 一次导入的输入，AWS 格式需要 config 和 credentials 两个文件，其他格式只用 Config
+type Source struct {
+	Config      []byte
+	Credentials []byte
+}
+
+// Parse 按格式解析配置文件中的节点，返回的节点尚未分配 ID。
+// unsupported 为配置中存在但无法表示为节点的条目及原因
+func Parse(format string, src Source) (result []nodes.Node, unsupported []Skipped, err error) {
+	switch format {
+	case FormatRclone:
+		result, err = ParseRclone(src.Config)
+	case FormatS3cmd:
+		result, err = ParseS3cmd(src.Config)
+	case FormatMC:
+		result, err = ParseMC(src.Config)
+	case FormatAWS:
+		return ParseAWS(src.Config, src.Credentials)
+	default:
+		err = fmt.Errorf("unknown config format %q", format)
+	}
+	return result, nil, err
+}
+
+// DefaultPaths 返回该格式配置文件的默认位置，AWS 格式返回 config 和 credentials 两个路径
+func DefaultPaths(format string) ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatRclone:
+		if path := os.Getenv("RCLONE_CONFIG"); path != "" {
+			return []string{path}, nil
+		}
+		path := filepath.Join(home, ".config", "rclone", "rclone.conf")
+		if _, err := os.Stat(path); err != nil {
+			if dir, err := os.UserConfigDir(); err == nil {
+				path = filepath.Join(dir, "rclone", "rclone.conf")
+			}
+		}
+		return []string{path}, nil
+	case FormatS3cmd:
+		return []string{filepath.Join(home, ".s3cfg")}, nil
+	case FormatMC:
+		return []string{filepath.Join(home, ".mc", "config.json")}, nil
+	case FormatAWS:
+		config := os.Getenv("AWS_CONFIG_FILE")
+		if config == "" {
+			config = filepath.Join(home, ".aws", "config")
+		}
+		credentials := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+		if credentials == "" {
+			credentials = filepath.Join(home, ".aws", "credentials")
+		}
+		return []string{config, credentials}, nil
+	default:
+		return nil, fmt.Errorf("unknown config format %q", format)
+	}
+}
+
+// Skipped 预览中被跳过的节点及原因
+type Skipped struct {
+	Node   nodes.Node `json:"node"`
+	Reason string     `json:"reason"`
+}
+
+// Plan 导入预览：将要添加的节点和被跳过的重复或不支持的节点
+type Plan struct {
+	Add     []nodes.Node `json:"add"`
+	Skipped []Skipped    `json:"skipped"`
+}
+
+// PlanImport 对比已有节点，按端点+AccessKey 跳过重复项，并为重名节点加上序号
+func PlanImport(existing, imported []nodes.Node) Plan {
+	plan := Plan{Add: []nodes.Node{}, Skipped: []Skipped{}}
+	seen := make(map[string]string)
+	names := make(map[string]bool)
+	for _, node := range existing {
+		seen[dedupeKey(node)] = node.NodeName
+		names[strings.ToLower(node.NodeName)] = true
+	}
+	for _, node := range imported {
+		key := dedupeKey(node)
+		if name, ok := seen[key]; ok {
+			plan.Skipped = append(plan.Skipped, Skipped{
+				Node:   node,
+				Reason: fmt.Sprintf("same endpoint and access key as %q", name),
+			})
+			continue
+		}
+		node.NodeName = uniqueName(names, node.NodeName)
+		names[strings.ToLower(node.NodeName)] = true
+		seen[key] = node.NodeName
+		plan.Add = append(plan.Add, node)
+	}
+	return plan
+}
+
+// dedupeKey 用规范化后的端点和 AccessKey 判断重复。
+// 没有 AccessKey 的节点(env/profile/process 来源)用凭证来源代替，扮演不同角色的节点不算重复
+func dedupeKey(node nodes.Node) string {
+	identity := node.AccessKey
+	if identity == "" {
+		identity = node.Source() + ":" + node.Profile + node.CredentialProcess
+	}
+	return normalizeEndpoint(node.EndPoint) + "|" + identity + "|" + node.RoleARN
+}
+
+// normalizeEndpoint 去掉协议、末尾斜杠并转小写
+func normalizeEndpoint(endpoint string) string {
+	endpoint = strings.ToLower(strings.TrimSpace(endpoint))
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		endpoint = u.Host + u.Path
+	}
+	return strings.TrimRight(endpoint, "/")
+}
+
+// uniqueName 名称已被占用时追加 " (2)"、" (3)" 等序号
+func uniqueName(names map[string]bool, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "imported"
+	}
+	if !names[strings.ToLower(name)] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if !names[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_.+@-]+`)
+
+// safeName 将节点名转换为可用作配置节名/别名的字符串
+func safeName(name string) string {
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		return "node"
+	}
+	return name
+}
+
+// exportNames 为每个节点生成互不相同的 safeName，用作配置节名、别名或文件名。
+// 不同的节点名转换后相同时，后出现的依次追加 -2、-3 等序号
+func exportNames(nodeList []nodes.Node) []string {
+	used := make(map[string]bool)
+	result := make([]string, len(nodeList))
+	for i, node := range nodeList {
+		base := safeName(node.NodeName)
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[strings.ToLower(name)] = true
+		result[i] = name
+	}
+	return result
+}
+
+// hostOf 返回端点中的主机部分(含端口)，以及端点是否为 http
+func hostOf(endpoint string) (string, bool) {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host, u.Scheme == "http"
+	}
+	return strings.TrimRight(endpoint, "/"), false
+}
+
+// endpointURL 为没有协议的端点补上协议
+func endpointURL(endpoint string, disableSSL bool) string {
+	if endpoint == "" || strings.Contains(endpoint, "://") {
+		return endpoint
+	}
+	if disableSSL {
+		return "http://" + endpoint
+	}
+	return "https://" + endpoint
+}
+
+// isTrue 解析各工具配置中的布尔值
+func isTrue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
+
+// exportable 按 supported 拆分节点，凭证来源无法在目标格式中表示的节点放入 skipped，
+// 避免导出空白密钥
+func exportable(format string, nodeList []nodes.Node, supported ...string) (kept []nodes.Node, skipped []Skipped) {
+	for _, node := range nodeList {
+		if slices.Contains(supported, node.Source()) {
+			kept = append(kept, node)
+			continue
+		}
+		skipped = append(skipped, Skipped{
+			Node:   node,
+			Reason: fmt.Sprintf("%s config cannot express %s credentials", format, node.Source()),
+		})
+	}
+	return kept, skipped
+}
+
+// Export 按格式导出节点，返回文件名到文件内容的映射，以及因凭证来源无法表示而跳过的节点。
+// s3cmd 的配置文件只能描述一个账号，因此每个节点单独生成一个 <名称>.s3cfg
+func Export(format string, nodeList []nodes.Node) (map[string][]byte, []Skipped, error) {
+	var files map[string][]byte
+	var skipped []Skipped
+	total := len(nodeList)
+	switch format {
+	case FormatRclone:
+		var content []byte
+		content, skipped = ExportRclone(nodeList)
+		files = map[string][]byte{"rclone.conf": content}
+	case FormatS3cmd:
+		nodeList, skipped = exportable(format, nodeList, nodes.CredentialStatic)
+		files = make(map[string][]byte)
+		names := exportNames(nodeList)
+		for i, node := range nodeList {
+			content, err := ExportS3cmd(node)
+			if err != nil {
+				return nil, nil, err
+			}
+			files[names[i]+".s3cfg"] = content
+		}
+	case FormatMC:
+		content, mcSkipped, err := ExportMC(nodeList)
+		if err != nil {
+			return nil, nil, err
+		}
+		files, skipped = map[string][]byte{"config.json": content}, mcSkipped
+	case FormatAWS:
+		config, credentials := ExportAWS(nodeList)
+		return map[string][]byte{"config": config, "credentials": credentials}, []Skipped{}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown config format %q", format)
+	}
+	if total > 0 && len(skipped) == total {
+		return nil, skipped, fmt.Errorf("none of the selected nodes can be exported as %s: %s", format, skipped[0].Reason)
+	}
+	if skipped == nil {
+		skipped = []Skipped{}
+	}
+	return files, skipped, nil
+}
@@ -0,0 +1,290 @@
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// roundTrip exports nodeList in format and parses the result again
+func roundTrip(t *testing.T, format string, nodeList []nodes.Node) []nodes.Node {
+	t.Helper()
+	files, skipped, err := Export(format, nodeList)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if len(skipped) > 0 {
+		t.Fatalf("Export skipped %+v", skipped)
+	}
+
+	var parsed []nodes.Node
+	switch format {
+	case FormatS3cmd:
+		// One file per node; files are named after the node
+		for _, name := range exportNames(nodeList) {
+			result, _, err := Parse(format, Source{Config: files[name+".s3cfg"]})
+			if err != nil {
+				t.Fatalf("Parse %s: %v", name, err)
+			}
+			parsed = append(parsed, result...)
+		}
+	case FormatAWS:
+		var unsupported []Skipped
+		parsed, unsupported, err = Parse(format, Source{Config: files["config"], Credentials: files["credentials"]})
+		if len(unsupported) > 0 {
+			t.Fatalf("Parse reported unsupported profiles %+v", unsupported)
+		}
+	case FormatRclone:
+		parsed, _, err = Parse(format, Source{Config: files["rclone.conf"]})
+	case FormatMC:
+		parsed, _, err = Parse(format, Source{Config: files["config.json"]})
+	}
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return parsed
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		format string
+		nodes  []nodes.Node
+		// rename maps an exported node name to the name the parser gives it
+		rename func(string) string
+	}{
+		{
+			format: FormatRclone,
+			nodes: []nodes.Node{
+				{NodeName: "minio", EndPoint: "https://minio.example.com:9000", AccessKey: "AK1", SecretKey: "SK1", Region: "us-east-1"},
+				{NodeName: "aws-temp", EndPoint: "https://s3.eu-west-1.amazonaws.com", AccessKey: "ASIA2", SecretKey: "SK2", SessionToken: "token", Region: "eu-west-1", AddressingStyle: nodes.AddressingVirtual},
+				{NodeName: "from-env", EndPoint: "https://s3.example.com", Region: "us-west-2", CredentialSource: nodes.CredentialEnv},
+			},
+		},
+		{
+			format: FormatMC,
+			nodes: []nodes.Node{
+				{NodeName: "local", EndPoint: "http://127.0.0.1:9000", AccessKey: "minioadmin", SecretKey: "minioadmin", Region: "us-east-1", DisableSSL: true},
+				{NodeName: "play", EndPoint: "https://play.min.io", AccessKey: "AK", SecretKey: "SK", Region: "us-east-1", AddressingStyle: nodes.AddressingVirtual},
+			},
+		},
+		{
+			format: FormatS3cmd,
+			nodes: []nodes.Node{
+				{NodeName: "s3cmd", EndPoint: "https://s3.example.com", AccessKey: "AK", SecretKey: "SK", SessionToken: "token", Region: "ap-south-1", AddressingStyle: nodes.AddressingVirtual, CABundle: "/etc/ssl/ca.pem", SkipTLSVerify: true},
+				{NodeName: "s3cmd", EndPoint: "http://ceph.local:7480", AccessKey: "AK", SecretKey: "SK", Region: "us-east-1", DisableSSL: true},
+			},
+		},
+		{
+			format: FormatAWS,
+			nodes: []nodes.Node{
+				{NodeName: "prod", EndPoint: "https://s3.us-east-1.amazonaws.com", AccessKey: "AK", SecretKey: "SK", Region: "us-east-1", AddressingStyle: nodes.AddressingVirtual},
+				{NodeName: "admin", EndPoint: "https://s3.us-east-1.amazonaws.com", AccessKey: "AK", SecretKey: "SK", Region: "us-east-1", AddressingStyle: nodes.AddressingVirtual,
+					RoleARN: "arn:aws:iam::123456789012:role/admin", ExternalID: "ext", RoleSessionName: "session", RoleDuration: 3600},
+				{NodeName: "sso", EndPoint: "https://minio.example.com", Region: "eu-central-1", AddressingStyle: nodes.AddressingPath,
+					CredentialSource: nodes.CredentialProcess, CredentialProcess: "aws-vault export --format=json sso"},
+				{NodeName: "ci", EndPoint: "https://s3.example.com", Region: "us-west-2", AddressingStyle: nodes.AddressingPath,
+					CredentialSource: nodes.CredentialEnv, RoleARN: "arn:aws:iam::123456789012:role/ci"},
+			},
+			rename: func(name string) string { return "aws-" + name },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			want := make([]nodes.Node, len(tt.nodes))
+			copy(want, tt.nodes)
+			if tt.rename != nil {
+				for i := range want {
+					want[i].NodeName = tt.rename(want[i].NodeName)
+				}
+			}
+
+			got := roundTrip(t, tt.format, tt.nodes)
+			if len(got) != len(want) {
+				t.Fatalf("parsed %d nodes, want %d:\n%+v", len(got), len(want), got)
+			}
+			// mc and AWS list entries by name
+			byName := make(map[string]nodes.Node)
+			for _, node := range got {
+				byName[node.NodeName] = node
+			}
+			for i, w := range want {
+				g := got[i]
+				if tt.format == FormatMC || tt.format == FormatAWS {
+					g = byName[w.NodeName]
+				}
+				if !reflect.DeepEqual(g, w) {
+					t.Errorf("round trip changed node:\n got  %+v\n want %+v", g, w)
+				}
+			}
+		})
+	}
+}
+
+func TestExportSkipsUnsupportedCredentials(t *testing.T) {
+	static := nodes.Node{NodeName: "static", EndPoint: "https://s3.example.com", AccessKey: "AK", SecretKey: "SK", Region: "us-east-1"}
+	env := nodes.Node{NodeName: "env", EndPoint: "https://s3.example.com", Region: "us-east-1", CredentialSource: nodes.CredentialEnv}
+	profile := nodes.Node{NodeName: "profile", EndPoint: "https://s3.example.com", Region: "us-east-1", CredentialSource: nodes.CredentialProfile, Profile: "dev"}
+	process := nodes.Node{NodeName: "process", EndPoint: "https://s3.example.com", Region: "us-east-1", CredentialSource: nodes.CredentialProcess, CredentialProcess: "creds"}
+	all := []nodes.Node{static, env, profile, process}
+
+	tests := []struct {
+		format      string
+		wantSkipped []string
+	}{
+		{FormatRclone, []string{"profile", "process"}},
+		{FormatMC, []string{"env", "profile", "process"}},
+		{FormatS3cmd, []string{"env", "profile", "process"}},
+		{FormatAWS, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			files, skipped, err := Export(tt.format, all)
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			var names []string
+			for _, s := range skipped {
+				names = append(names, s.Node.NodeName)
+				if !strings.Contains(s.Reason, s.Node.Source()) {
+					t.Errorf("reason %q does not name the %s credential source", s.Reason, s.Node.Source())
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantSkipped) {
+				t.Errorf("skipped %v, want %v", names, tt.wantSkipped)
+			}
+			for name, content := range files {
+				for _, skippedName := range tt.wantSkipped {
+					if strings.Contains(string(content), skippedName) {
+						t.Errorf("%s mentions skipped node %q:\n%s", name, skippedName, content)
+					}
+				}
+			}
+		})
+	}
+
+	// Nothing left to export is an error rather than an empty file
+	if _, skipped, err := Export(FormatMC, []nodes.Node{env, process}); err == nil {
+		t.Errorf("exporting only unsupported nodes succeeded, want an error")
+	} else if len(skipped) != 2 {
+		t.Errorf("got %d skipped nodes with the error, want 2", len(skipped))
+	}
+}
+
+func TestParseAWSRoleSources(t *testing.T) {
+	config := []byte(`[profile base]
+region = us-east-1
+
+[profile chained]
+role_arn = arn:aws:iam::123456789012:role/chained
+source_profile = base
+
+[profile from-env]
+role_arn = arn:aws:iam::123456789012:role/env
+credential_source = Environment
+
+[profile ec2]
+role_arn = arn:aws:iam::123456789012:role/ec2
+credential_source = Ec2InstanceMetadata
+
+[profile ecs]
+role_arn = arn:aws:iam::123456789012:role/ecs
+credential_source = EcsContainer
+
+[profile dangling]
+role_arn = arn:aws:iam::123456789012:role/dangling
+source_profile = missing
+
+[profile bare]
+role_arn = arn:aws:iam::123456789012:role/bare
+`)
+	credentials := []byte(`[base]
+aws_access_key_id = AKBASE
+aws_secret_access_key = SKBASE
+`)
+	result, unsupported, err := ParseAWS(config, credentials)
+	if err != nil {
+		t.Fatalf("ParseAWS: %v", err)
+	}
+
+	got := make(map[string]nodes.Node)
+	for _, node := range result {
+		got[node.NodeName] = node
+	}
+	if node := got["aws-chained"]; node.AccessKey != "AKBASE" || node.RoleARN == "" {
+		t.Errorf("chained profile = %+v, want the base keys and its role", node)
+	}
+	if node := got["aws-from-env"]; node.Source() != nodes.CredentialEnv || node.RoleARN == "" {
+		t.Errorf("from-env profile = %+v, want env credentials and its role", node)
+	}
+
+	wantUnsupported := map[string]string{
+		"aws-ec2":      "Ec2InstanceMetadata",
+		"aws-ecs":      "EcsContainer",
+		"aws-dangling": "missing",
+		"aws-bare":     "source_profile",
+	}
+	if len(unsupported) != len(wantUnsupported) {
+		t.Errorf("unsupported = %+v, want %d entries", unsupported, len(wantUnsupported))
+	}
+	for _, s := range unsupported {
+		if _, ok := got[s.Node.NodeName]; ok {
+			t.Errorf("%s is both imported and unsupported", s.Node.NodeName)
+		}
+		if want, ok := wantUnsupported[s.Node.NodeName]; !ok {
+			t.Errorf("%s unexpectedly unsupported: %s", s.Node.NodeName, s.Reason)
+		} else if !strings.Contains(s.Reason, want) {
+			t.Errorf("%s reason %q does not mention %q", s.Node.NodeName, s.Reason, want)
+		}
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	existing := []nodes.Node{
+		{NodeName: "prod", EndPoint: "https://s3.example.com/", AccessKey: "AK1"},
+		{NodeName: "admin", EndPoint: "https://s3.example.com", AccessKey: "AK1", RoleARN: "arn:aws:iam::1:role/admin"},
+	}
+	imported := []nodes.Node{
+		// Same endpoint and key, scheme, case and trailing slash differ
+		{NodeName: "prod-copy", EndPoint: "HTTP://S3.example.com", AccessKey: "AK1"},
+		// Same identity assuming the existing role
+		{NodeName: "admin-copy", EndPoint: "s3.example.com", AccessKey: "AK1", RoleARN: "arn:aws:iam::1:role/admin"},
+		// Same identity assuming a different role
+		{NodeName: "readonly", EndPoint: "https://s3.example.com", AccessKey: "AK1", RoleARN: "arn:aws:iam::1:role/readonly"},
+		// Different key, name already taken
+		{NodeName: "Prod", EndPoint: "https://s3.example.com", AccessKey: "AK2"},
+		// Duplicate within the imported set
+		{NodeName: "other", EndPoint: "https://s3.example.com", AccessKey: "AK2"},
+		// Different endpoint
+		{NodeName: "prod", EndPoint: "https://s3.other.com", AccessKey: "AK1"},
+	}
+
+	plan := PlanImport(existing, imported)
+
+	var added []string
+	for _, node := range plan.Add {
+		added = append(added, node.NodeName)
+	}
+	wantAdded := []string{"readonly", "Prod (2)", "prod (3)"}
+	if !reflect.DeepEqual(added, wantAdded) {
+		t.Errorf("added %v, want %v", added, wantAdded)
+	}
+
+	wantSkipped := map[string]string{
+		"prod-copy":  `"prod"`,
+		"admin-copy": `"admin"`,
+		"other":      `"Prod (2)"`,
+	}
+	if len(plan.Skipped) != len(wantSkipped) {
+		t.Errorf("skipped %+v, want %d entries", plan.Skipped, len(wantSkipped))
+	}
+	for _, s := range plan.Skipped {
+		want, ok := wantSkipped[s.Node.NodeName]
+		if !ok {
+			t.Errorf("%s unexpectedly skipped: %s", s.Node.NodeName, s.Reason)
+		} else if !strings.Contains(s.Reason, want) {
+			t.Errorf("%s skip reason %q does not name %s", s.Node.NodeName, s.Reason, want)
+		}
+	}
+}
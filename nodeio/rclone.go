@@ -0,0 +1,66 @@
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"strconv"
+)
+
+// ParseRclone 解析 rclone.conf 中 type = s3 的 remote
+func ParseRclone(data []byte) ([]nodes.Node, error) {
+	var result []nodes.Node
+	for _, section := range parseINI(data) {
+		if section.Get("type") != "s3" {
+			continue
+		}
+		node := nodes.Node{
+			NodeName:  section.Name,
+			EndPoint:  section.Get("endpoint"),
+			AccessKey: section.Get("access_key_id"),
+			SecretKey: section.Get("secret_access_key"),
+			Region:    section.Get("region"),
+		}
+		if token := section.Get("session_token"); token != "" {
+			node.SessionToken = token
+		}
+		if isTrue(section.Get("env_auth")) && node.AccessKey == "" {
+			node.CredentialSource = nodes.CredentialEnv
+		}
+		// rclone 默认使用路径风格，仅当显式关闭时改为虚拟主机风格
+		if value := section.Get("force_path_style"); value != "" && !isTrue(value) {
+			node.AddressingStyle = nodes.AddressingVirtual
+		}
+		if node.EndPoint == "" {
+			node.EndPoint = awsEndpoint(node.Region)
+			node.AddressingStyle = nodes.AddressingVirtual
+		}
+		if node.Region == "" {
+			node.Region = defaultRegion
+		}
+		result = append(result, node)
+	}
+	return result, nil
+}
+
+// ExportRclone 将节点导出为 rclone.conf 格式，每个节点一个 remote。
+// rclone 无法引用 profile 或 credential_process，这类节点被跳过并返回
+func ExportRclone(nodeList []nodes.Node) ([]byte, []Skipped) {
+	nodeList, skipped := exportable(FormatRclone, nodeList, nodes.CredentialStatic, nodes.CredentialEnv)
+	w := &iniWriter{}
+	names := exportNames(nodeList)
+	for i, node := range nodeList {
+		w.Section(names[i])
+		w.Key("type", "s3")
+		w.Key("provider", "Other")
+		if node.Source() == nodes.CredentialEnv {
+			w.Key("env_auth", "true")
+		} else {
+			w.Key("access_key_id", node.AccessKey)
+			w.Key("secret_access_key", node.SecretKey)
+			w.Key("session_token", node.SessionToken)
+		}
+		w.Key("region", node.Region)
+		w.Key("endpoint", endpointURL(node.EndPoint, node.DisableSSL))
+		w.Key("force_path_style", strconv.FormatBool(node.UsePathStyle()))
+	}
+	return w.Bytes(), skipped
+}
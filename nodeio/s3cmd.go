@@ -0,0 +1,83 @@
+package nodeio
+
+import (
+	nodes "SRSC-Client/type"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseS3cmd 解析 ~/.s3cfg，s3cmd 的配置文件只描述一个账号
+func ParseS3cmd(data []byte) ([]nodes.Node, error) {
+	for _, section := range parseINI(data) {
+		if section.Name != "default" {
+			continue
+		}
+		disableSSL := section.Get("use_https") != "" && !isTrue(section.Get("use_https"))
+		node := nodes.Node{
+			NodeName:     "s3cmd",
+			AccessKey:    section.Get("access_key"),
+			SecretKey:    section.Get("secret_key"),
+			SessionToken: section.Get("access_token"),
+			Region:       section.Get("bucket_location"),
+			DisableSSL:   disableSSL,
+		}
+		host := section.Get("host_base")
+		if host == "" {
+			host = "s3.amazonaws.com"
+		}
+		node.EndPoint = endpointURL(host, disableSSL)
+		if strings.Contains(section.Get("host_bucket"), "%(bucket)s") {
+			node.AddressingStyle = nodes.AddressingVirtual
+		}
+		// s3cmd 用 "US" 表示 us-east-1
+		if node.Region == "" || strings.EqualFold(node.Region, "US") {
+			node.Region = defaultRegion
+		}
+		if proxy := section.Get("proxy_host"); proxy != "" {
+			node.Proxy = "http://" + proxy
+			if port := section.Get("proxy_port"); port != "" && port != "0" {
+				node.Proxy += ":" + port
+			}
+		}
+		if ca := section.Get("ca_certs_file"); ca != "" {
+			node.CABundle = ca
+		}
+		if section.Get("check_ssl_certificate") != "" && !isTrue(section.Get("check_ssl_certificate")) {
+			node.SkipTLSVerify = true
+		}
+		return []nodes.Node{node}, nil
+	}
+	return nil, errors.New("no [default] section found in s3cmd config")
+}
+
+// ExportS3cmd 将单个节点导出为 .s3cfg 格式，s3cmd 只能保存静态密钥
+func ExportS3cmd(node nodes.Node) ([]byte, error) {
+	if node.Source() != nodes.CredentialStatic {
+		return nil, fmt.Errorf("s3cmd config cannot express %s credentials of node %q", node.Source(), node.NodeName)
+	}
+	host, isHTTP := hostOf(node.EndPoint)
+	hostBucket := host
+	if !node.UsePathStyle() {
+		hostBucket = "%(bucket)s." + host
+	}
+	useHTTPS := "True"
+	if node.DisableSSL || isHTTP {
+		useHTTPS = "False"
+	}
+
+	w := &iniWriter{}
+	w.Section("default")
+	w.Key("access_key", node.AccessKey)
+	w.Key("secret_key", node.SecretKey)
+	w.Key("access_token", node.SessionToken)
+	w.Key("host_base", host)
+	w.Key("host_bucket", hostBucket)
+	w.Key("bucket_location", node.Region)
+	w.Key("use_https", useHTTPS)
+	w.Key("ca_certs_file", node.CABundle)
+	if node.SkipTLSVerify {
+		w.Key("check_ssl_certificate", "False")
+	}
+	return w.Bytes(), nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	nodes "SRSC-Client/type"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// errCodeNoObjectLock 桶或对象没有对象锁配置时返回的错误码
+const errCodeNoObjectLock = "ObjectLockConfigurationNotFoundError"
+
+// GetObjectLockConfig 返回桶的对象锁配置，未启用时 Enabled 为 false
+func (a *S3Manager) GetObjectLockConfig(nodeID, bucketName string) (nodes.ObjectLockConfig, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetObjectLockConfig: "+err.Error())
+		return nodes.ObjectLockConfig{}, err
+	}
+	out, err := s3Manager.client.GetObjectLockConfigurationWithContext(context.Background(),
+		&s3.GetObjectLockConfigurationInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		if awsErrorCode(err) == errCodeNoObjectLock {
+			return nodes.ObjectLockConfig{}, nil
+		}
+		logAWSError("Failed to get object lock configuration", err)
+		return nodes.ObjectLockConfig{}, fmt.Errorf("v1: failed to get object lock configuration for %s: %v", bucketName, err)
+	}
+	return objectLockConfigFromS3(out.ObjectLockConfiguration), nil
+}
+
+// objectLockConfigFromS3 将 SDK 的对象锁配置转换为 nodes.ObjectLockConfig
+func objectLockConfigFromS3(in *s3.ObjectLockConfiguration) nodes.ObjectLockConfig {
+	var config nodes.ObjectLockConfig
+	if in == nil {
+		return config
+	}
+	config.Enabled = aws.StringValue(in.ObjectLockEnabled) == s3.ObjectLockEnabledEnabled
+	if in.Rule != nil && in.Rule.DefaultRetention != nil {
+		config.Mode = aws.StringValue(in.Rule.DefaultRetention.Mode)
+		config.Days = aws.Int64Value(in.Rule.DefaultRetention.Days)
+		config.Years = aws.Int64Value(in.Rule.DefaultRetention.Years)
+	}
+	return config
+}
+
+// PutObjectLockConfig 启用桶的对象锁并设置默认保留规则。
+// 已有桶需要先启用版本控制；对象锁一旦启用就不能关闭
+func (a *S3Manager) PutObjectLockConfig(nodeID, bucketName string, config nodes.ObjectLockConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutObjectLockConfig: "+err.Error())
+		return err
+	}
+	lock := &s3.ObjectLockConfiguration{ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled)}
+	if config.Mode != "" {
+		retention := &s3.DefaultRetention{Mode: aws.String(config.Mode)}
+		if config.Days > 0 {
+			retention.Days = aws.Int64(config.Days)
+		} else {
+			retention.Years = aws.Int64(config.Years)
+		}
+		lock.Rule = &s3.ObjectLockRule{DefaultRetention: retention}
+	}
+	_, err = s3Manager.client.PutObjectLockConfigurationWithContext(context.Background(), &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(bucketName),
+		ObjectLockConfiguration: lock,
+	})
+	if err != nil {
+		logAWSError("Failed to put object lock configuration", err)
+		return fmt.Errorf("v1: failed to put object lock configuration for %s: %v", bucketName, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Object lock configuration for %s set to %+v", bucketName, config))
+	return nil
+}
+
+// GetObjectRetention 返回对象版本的保留设置，versionID 为空表示当前版本；没有保留时 Mode 为空
+func (a *S3Manager) GetObjectRetention(nodeID, bucketName, objectKey, versionID string) (nodes.ObjectRetention, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetObjectRetention: "+err.Error())
+		return nodes.ObjectRetention{}, err
+	}
+	out, err := s3Manager.client.GetObjectRetentionWithContext(context.Background(), &s3.GetObjectRetentionInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+	})
+	if err != nil {
+		if code := awsErrorCode(err); code == "NoSuchObjectLockConfiguration" || code == errCodeNoObjectLock {
+			return nodes.ObjectRetention{}, nil
+		}
+		logAWSError("Failed to get object retention", err)
+		return nodes.ObjectRetention{}, fmt.Errorf("v1: failed to get retention for %s/%s: %v", bucketName, objectKey, err)
+	}
+	var retention nodes.ObjectRetention
+	if out.Retention != nil {
+		retention.Mode = aws.StringValue(out.Retention.Mode)
+		retention.RetainUntilDate = aws.TimeValue(out.Retention.RetainUntilDate)
+	}
+	return retention, nil
+}
+
+// PutObjectRetention 设置对象版本的保留模式和截止时间。
+// 缩短或移除 GOVERNANCE 保留需要 bypassGovernance，传入空的 retention 表示移除；COMPLIANCE 保留只能延长
+func (a *S3Manager) PutObjectRetention(nodeID, bucketName, objectKey, versionID string, retention nodes.ObjectRetention, bypassGovernance bool) error {
+	lockRetention := &s3.ObjectLockRetention{}
+	if retention.IsZero() {
+		if !bypassGovernance {
+			return fmt.Errorf("removing retention from %s requires bypassing governance mode", objectKey)
+		}
+	} else {
+		if err := retention.Validate(); err != nil {
+			return err
+		}
+		lockRetention.Mode = aws.String(retention.Mode)
+		lockRetention.RetainUntilDate = aws.Time(retention.RetainUntilDate)
+	}
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for PutObjectRetention: "+err.Error())
+		return err
+	}
+	_, err = s3Manager.client.PutObjectRetentionWithContext(context.Background(), &s3.PutObjectRetentionInput{
+		Bucket:                    aws.String(bucketName),
+		Key:                       aws.String(objectKey),
+		VersionId:                 optionalString(versionID),
+		Retention:                 lockRetention,
+		BypassGovernanceRetention: aws.Bool(bypassGovernance),
+	})
+	if err != nil {
+		logAWSError("Failed to put object retention", err)
+		return fmt.Errorf("v1: failed to set retention for %s/%s: %v", bucketName, objectKey, err)
+	}
+	if retention.IsZero() {
+		runtime.LogDebug(ContextX, fmt.Sprintf("v1: Retention removed from %s/%s", bucketName, objectKey))
+		return nil
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Retention for %s/%s set to %s until %s",
+		bucketName, objectKey, retention.Mode, retention.RetainUntilDate.Format(time.RFC3339)))
+	return nil
+}
+
+// GetObjectLegalHold 返回对象版本是否处于法律保留状态
+func (a *S3Manager) GetObjectLegalHold(nodeID, bucketName, objectKey, versionID string) (bool, error) {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for GetObjectLegalHold: "+err.Error())
+		return false, err
+	}
+	out, err := s3Manager.client.GetObjectLegalHoldWithContext(context.Background(), &s3.GetObjectLegalHoldInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+	})
+	if err != nil {
+		if code := awsErrorCode(err); code == "NoSuchObjectLockConfiguration" || code == errCodeNoObjectLock {
+			return false, nil
+		}
+		logAWSError("Failed to get object legal hold", err)
+		return false, fmt.Errorf("v1: failed to get legal hold for %s/%s: %v", bucketName, objectKey, err)
+	}
+	return out.LegalHold != nil && aws.StringValue(out.LegalHold.Status) == s3.ObjectLockLegalHoldStatusOn, nil
+}
+
+// SetObjectLegalHold 开启或解除对象版本的法律保留
+func (a *S3Manager) SetObjectLegalHold(nodeID, bucketName, objectKey, versionID string, on bool) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for SetObjectLegalHold: "+err.Error())
+		return err
+	}
+	status := s3.ObjectLockLegalHoldStatusOff
+	if on {
+		status = s3.ObjectLockLegalHoldStatusOn
+	}
+	_, err = s3Manager.client.PutObjectLegalHoldWithContext(context.Background(), &s3.PutObjectLegalHoldInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+		LegalHold: &s3.ObjectLockLegalHold{Status: aws.String(status)},
+	})
+	if err != nil {
+		logAWSError("Failed to set object legal hold", err)
+		return fmt.Errorf("v1: failed to set legal hold for %s/%s: %v", bucketName, objectKey, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Legal hold for %s/%s set to %s", bucketName, objectKey, status))
+	return nil
+}
+
+// DeleteObject 删除对象；versionID 为空时删除当前版本(版本化桶中会生成删除标记)。
+// 删除 GOVERNANCE 保留期内的版本需要 bypassGovernance。删除被对象锁阻止时返回说明原因的错误
+func (a *S3Manager) DeleteObject(nodeID, bucketName, objectKey, versionID string, bypassGovernance bool) error {
+	s3Manager, err := a.NewS3Client(nodeID)
+	if err != nil {
+		runtime.LogError(ContextX, "v1: Connection failed for DeleteObject: "+err.Error())
+		return err
+	}
+	ctx := context.Background()
+	input := &s3.DeleteObjectInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+	}
+	if bypassGovernance {
+		input.BypassGovernanceRetention = aws.Bool(true)
+	}
+	if _, err := s3Manager.client.DeleteObjectWithContext(ctx, input); err != nil {
+		logAWSError("Failed to delete object", err)
+		if awsErrorCode(err) == "AccessDenied" {
+			if reason := s3Manager.objectLockReason(ctx, bucketName, objectKey, versionID); reason != "" {
+				return fmt.Errorf("cannot delete %s/%s: %s", bucketName, objectKey, reason)
+			}
+		}
+		return fmt.Errorf("v1: failed to delete %s/%s: %v", bucketName, objectKey, err)
+	}
+	runtime.LogDebug(ContextX, fmt.Sprintf("v1: Deleted %s/%s", bucketName, objectKey))
+	return nil
+}
+
+// objectLockReason 检查对象版本是否受法律保留或保留期保护，返回可读的原因，未受保护时返回空字符串
+func (a *S3Manager) objectLockReason(ctx context.Context, bucketName, objectKey, versionID string) string {
+	var reasons []string
+	hold, err := a.client.GetObjectLegalHoldWithContext(ctx, &s3.GetObjectLegalHoldInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+	})
+	if err == nil && hold.LegalHold != nil && aws.StringValue(hold.LegalHold.Status) == s3.ObjectLockLegalHoldStatusOn {
+		reasons = append(reasons, "the object is under legal hold")
+	}
+	retention, err := a.client.GetObjectRetentionWithContext(ctx, &s3.GetObjectRetentionInput{
+		Bucket:    aws.String(bucketName),
+		Key:       aws.String(objectKey),
+		VersionId: optionalString(versionID),
+	})
+	if err == nil && retention.Retention != nil {
+		until := aws.TimeValue(retention.Retention.RetainUntilDate)
+		if until.After(time.Now()) {
+			mode := aws.StringValue(retention.Retention.Mode)
+			reason := fmt.Sprintf("the object is under %s retention until %s", mode, until.Format(time.RFC3339))
+			if mode == nodes.RetentionGovernance {
+				reason += " (retry with governance bypass if you have permission)"
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	return "blocked by object lock: " + strings.Join(reasons, " and ")
+}
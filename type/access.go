@@ -0,0 +1,77 @@
+package nodes
+
+import "fmt"
+
+// PublicAccessBlock 桶的公共访问阻止设置，四个开关相互独立
+type PublicAccessBlock struct {
+	BlockPublicAcls       bool `json:"blockPublicAcls"`       // 拒绝设置公共 ACL
+	IgnorePublicAcls      bool `json:"ignorePublicAcls"`      // 忽略已有的公共 ACL
+	BlockPublicPolicy     bool `json:"blockPublicPolicy"`     // 拒绝设置公共桶策略
+	RestrictPublicBuckets bool `json:"restrictPublicBuckets"` // 限制对公共桶的访问
+}
+
+// AllBlocked 四个开关是否全部开启
+func (p PublicAccessBlock) AllBlocked() bool {
+	return p.BlockPublicAcls && p.IgnorePublicAcls && p.BlockPublicPolicy && p.RestrictPublicBuckets
+}
+
+// 预设 ACL
+const (
+	ACLPrivate           = "private"
+	ACLPublicRead        = "public-read"
+	ACLPublicReadWrite   = "public-read-write"
+	ACLAuthenticatedRead = "authenticated-read"
+)
+
+// 被授权者类型
+const (
+	GranteeCanonicalUser = "CanonicalUser"
+	GranteeGroup         = "Group"
+	GranteeEmail         = "AmazonCustomerByEmail"
+)
+
+// ACL 权限
+var aclPermissions = map[string]bool{
+	"FULL_CONTROL": true, "READ": true, "WRITE": true, "READ_ACP": true, "WRITE_ACP": true,
+}
+
+// ACLGrant 一条 ACL 授权
+type ACLGrant struct {
+	GranteeType string `json:"granteeType"` // CanonicalUser / Group / AmazonCustomerByEmail
+	ID          string `json:"id"`          // 规范用户 ID，GranteeType 为 CanonicalUser 时使用
+	DisplayName string `json:"displayName"` // 显示名称，只读
+	URI         string `json:"uri"`         // 用户组 URI，GranteeType 为 Group 时使用
+	Email       string `json:"email"`       // 邮箱，GranteeType 为 AmazonCustomerByEmail 时使用
+	Permission  string `json:"permission"`  // FULL_CONTROL / READ / WRITE / READ_ACP / WRITE_ACP
+}
+
+// BucketACL 桶的 ACL
+type BucketACL struct {
+	OwnerID          string     `json:"ownerId"`          // 所有者规范用户 ID，设置时为空表示沿用当前所有者
+	OwnerDisplayName string     `json:"ownerDisplayName"` // 所有者显示名称
+	Grants           []ACLGrant `json:"grants"`           // 授权列表
+}
+
+// Validate 检查授权是否完整
+func (g ACLGrant) Validate() error {
+	if !aclPermissions[g.Permission] {
+		return fmt.Errorf("unknown ACL permission %q", g.Permission)
+	}
+	switch g.GranteeType {
+	case GranteeCanonicalUser:
+		if g.ID == "" {
+			return fmt.Errorf("canonical user grant needs an ID")
+		}
+	case GranteeGroup:
+		if g.URI == "" {
+			return fmt.Errorf("group grant needs a URI")
+		}
+	case GranteeEmail:
+		if g.Email == "" {
+			return fmt.Errorf("email grant needs an email address")
+		}
+	default:
+		return fmt.Errorf("unknown grantee type %q", g.GranteeType)
+	}
+	return nil
+}
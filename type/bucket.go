@@ -1,29 +1,94 @@
 package nodes
 
 import (
+	"fmt"
+	"net"
+	"strings"
 	"time"
 )
 
 // BucketInfo 存储桶信息结构体
 type BucketInfo struct {
-	Name                string    `json:"name"`                // 桶名称
-	CreationDate        time.Time `json:"creationDate"`        // 创建时间
-	UsedSpace           int64     `json:"usedSpace"`           // 已用空间(字节)
-	TotalObjects        int64     `json:"totalObjects"`        // 对象总数
-	VersioningEnabled   bool      `json:"versioningEnabled"`   // 版本控制是否启用
-	PublicAccessBlocked bool      `json:"publicAccessBlocked"` // 是否阻止公共访问
-	HasPolicy           bool      `json:"hasPolicy"`           // 是否有桶策略
-	EncryptionEnabled   bool      `json:"encryptionEnabled"`   // 是否启用加密
-	EncryptionType      string    `json:"encryptionType"`      // 加密类型
-	HasLifecycleRules   bool      `json:"hasLifecycleRules"`   // 是否有生命周期规则
-	LifecycleRulesCount int       `json:"lifecycleRulesCount"` // 生命周期规则数量
-	Region              string    `json:"region"`              // 桶所在区域
-	WebsiteEnabled      bool      `json:"websiteEnabled"`      // 是否启用静态网站
+	Name                string            `json:"name"`                // 桶名称
+	CreationDate        time.Time         `json:"creationDate"`        // 创建时间
+	UsedSpace           int64             `json:"usedSpace"`           // 已用空间(字节)
+	TotalObjects        int64             `json:"totalObjects"`        // 对象总数
+	SizeIncomplete      bool              `json:"sizeIncomplete"`      // 对象统计未完成(取消或失败)，UsedSpace 和 TotalObjects 只是部分结果
+	VersioningEnabled   bool              `json:"versioningEnabled"`   // 版本控制是否启用
+	VersioningStatus    string            `json:"versioningStatus"`    // 版本控制状态: Off / Enabled / Suspended
+	MFADelete           string            `json:"mfaDelete"`           // MFA 删除状态: Enabled / Disabled，未返回时为空
+	PublicAccessBlocked bool              `json:"publicAccessBlocked"` // 是否阻止公共访问
+	PublicAccessBlock   PublicAccessBlock `json:"publicAccessBlock"`   // 公共访问阻止的四个开关
+	ACLGrants           []ACLGrant        `json:"aclGrants"`           // 桶 ACL 授权
+	CORSRules           []CORSRule        `json:"corsRules"`           // CORS 规则
+	Tags                []Tag             `json:"tags"`                // 桶标签
+	ObjectLock          ObjectLockConfig  `json:"objectLock"`          // 对象锁配置
+	ReplicationEnabled  bool              `json:"replicationEnabled"`  // 是否有启用的复制规则
+	Replication         ReplicationConfig `json:"replication"`         // 复制配置
+	HasPolicy           bool              `json:"hasPolicy"`           // 是否有桶策略
+	EncryptionEnabled   bool              `json:"encryptionEnabled"`   // 是否启用加密
+	EncryptionType      string            `json:"encryptionType"`      // 加密类型
+	EncryptionRules     []EncryptionRule  `json:"encryptionRules"`     // 全部默认加密规则
+	EncryptionError     string            `json:"encryptionError"`     // 读取加密配置失败时的错误；未配置加密不算错误
+	HasLifecycleRules   bool              `json:"hasLifecycleRules"`   // 是否有生命周期规则
+	LifecycleRulesCount int               `json:"lifecycleRulesCount"` // 生命周期规则数量
+	LifecycleRules      []LifecycleRule   `json:"lifecycleRules"`      // 生命周期规则
+	Region              string            `json:"region"`              // 桶所在区域
+	WebsiteEnabled      bool              `json:"websiteEnabled"`      // 是否启用静态网站
+	Website             WebsiteConfig     `json:"website"`             // 静态网站配置
 }
 
 // NodeBucketInfo 节点桶信息结构体
 type NodeBucketInfo struct {
-	NodeName string       `json:"nodeName"` // 节点名称
-	EndPoint string       `json:"endPoint"` // 节点端点
-	Buckets  []BucketInfo `json:"buckets"`  // 桶信息列表
+	NodeName  string       `json:"nodeName"`  // 节点名称
+	EndPoint  string       `json:"endPoint"`  // 节点端点
+	Buckets   []BucketInfo `json:"buckets"`   // 桶信息列表
+	Cancelled bool         `json:"cancelled"` // 获取被取消，结果可能不完整
+}
+
+// 版本控制状态。从未启用过版本控制的桶，S3 不返回状态，这里记为 Off
+const (
+	VersioningOff       = "Off"
+	VersioningEnabled   = "Enabled"
+	VersioningSuspended = "Suspended"
+)
+
+// VersioningConfig 桶的版本控制配置
+type VersioningConfig struct {
+	Status    string `json:"status"`    // Off / Enabled / Suspended，设置时只能为 Enabled 或 Suspended
+	MFADelete string `json:"mfaDelete"` // Enabled / Disabled，设置时为空表示不修改
+	MFA       string `json:"mfa"`       // 修改 MFA 删除时需要: "设备序列号 验证码"，读取时为空
+}
+
+// CreateBucketOptions 创建桶的选项
+type CreateBucketOptions struct {
+	Name       string `json:"name"`       // 桶名称
+	Region     string `json:"region"`     // 位置约束，为空时使用节点区域
+	ObjectLock bool   `json:"objectLock"` // 创建时启用对象锁(会同时启用版本控制)
+	Versioning bool   `json:"versioning"` // 创建后启用版本控制
+}
+
+// ValidateBucketName 按 S3 命名规则检查桶名称
+func ValidateBucketName(name string) error {
+	if len(name) < 3 || len(name) > 63 {
+		return fmt.Errorf("bucket name must be between 3 and 63 characters long")
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-' || c == '.':
+			if i == 0 || i == len(name)-1 {
+				return fmt.Errorf("bucket name must begin and end with a letter or number")
+			}
+		default:
+			return fmt.Errorf("bucket name may only contain lowercase letters, numbers, dots and hyphens")
+		}
+	}
+	if strings.Contains(name, "..") || strings.Contains(name, ".-") || strings.Contains(name, "-.") {
+		return fmt.Errorf("bucket name must not contain adjacent dots or dots next to hyphens")
+	}
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("bucket name must not be formatted as an IP address")
+	}
+	return nil
 }
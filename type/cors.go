@@ -0,0 +1,181 @@
+package nodes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// corsMethods S3 CORS 规则允许的方法
+var corsMethods = map[string]bool{"GET": true, "PUT": true, "POST": true, "DELETE": true, "HEAD": true}
+
+// CORSRule 桶的 CORS 规则
+type CORSRule struct {
+	ID             string   `json:"id"`             // 规则 ID，可为空
+	AllowedOrigins []string `json:"allowedOrigins"` // 允许的来源，可包含一个 * 通配符
+	AllowedMethods []string `json:"allowedMethods"` // 允许的方法: GET / PUT / POST / DELETE / HEAD
+	AllowedHeaders []string `json:"allowedHeaders"` // 预检请求中允许的请求头，可包含一个 * 通配符
+	ExposeHeaders  []string `json:"exposeHeaders"`  // 允许浏览器读取的响应头
+	MaxAgeSeconds  int64    `json:"maxAgeSeconds"`  // 预检结果缓存时间(秒)，0 表示不设置
+}
+
+// Validate 检查 CORS 规则
+func (r CORSRule) Validate() error {
+	if len(r.AllowedOrigins) == 0 {
+		return fmt.Errorf("CORS rule needs at least one allowed origin")
+	}
+	if len(r.AllowedMethods) == 0 {
+		return fmt.Errorf("CORS rule needs at least one allowed method")
+	}
+	for _, method := range r.AllowedMethods {
+		if !corsMethods[method] {
+			return fmt.Errorf("CORS method %q is not supported; use GET, PUT, POST, DELETE or HEAD", method)
+		}
+	}
+	for _, origin := range r.AllowedOrigins {
+		if origin == "" || strings.Count(origin, "*") > 1 {
+			return fmt.Errorf("allowed origin %q must be non-empty with at most one * wildcard", origin)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if header == "" || strings.Count(header, "*") > 1 {
+			return fmt.Errorf("allowed header %q must be non-empty with at most one * wildcard", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return fmt.Errorf("max age must not be negative")
+	}
+	return nil
+}
+
+// CORSRequest 需要检查的跨域请求
+type CORSRequest struct {
+	Origin  string   `json:"origin"`  // 请求来源，如 https://app.example.com
+	Method  string   `json:"method"`  // 请求方法，预检时为 Access-Control-Request-Method
+	Headers []string `json:"headers"` // 请求头，预检时为 Access-Control-Request-Headers，每项可以是逗号分隔的列表
+}
+
+// CORSEvaluation CORS 检查结果
+type CORSEvaluation struct {
+	Allowed         bool              `json:"allowed"`         // 是否允许
+	MatchedRule     int               `json:"matchedRule"`     // 生效规则的下标，未匹配时为 -1
+	Reasons         []string          `json:"reasons"`         // 每条规则未匹配的原因
+	ResponseHeaders map[string]string `json:"responseHeaders"` // 允许时 S3 返回的 CORS 响应头
+}
+
+// EvaluateCORS 按 S3 的规则模拟一次跨域请求：使用第一条来源、方法和全部请求头都匹配的规则
+func EvaluateCORS(rules []CORSRule, req CORSRequest) CORSEvaluation {
+	result := CORSEvaluation{MatchedRule: -1, Reasons: []string{}}
+	// 与浏览器发送的格式一致: 方法不区分大小写，请求头可以是 "content-type, x-amz-date" 这样的列表
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	req.Headers = splitHeaderList(req.Headers)
+	req.Origin = strings.TrimSpace(req.Origin)
+	if req.Origin == "" {
+		// 没有 Origin 的请求不经过 CORS 检查，* 规则也不会返回 CORS 响应头
+		result.Reasons = append(result.Reasons, "not a CORS request (no Origin header)")
+		return result
+	}
+	if len(rules) == 0 {
+		result.Reasons = append(result.Reasons, "bucket has no CORS configuration")
+		return result
+	}
+
+	for i, rule := range rules {
+		name := fmt.Sprintf("rule %d", i+1)
+		if rule.ID != "" {
+			name = fmt.Sprintf("rule %q", rule.ID)
+		}
+
+		matchedOrigin := ""
+		for _, origin := range rule.AllowedOrigins {
+			if wildcardMatch(origin, req.Origin, false) {
+				matchedOrigin = origin
+				break
+			}
+		}
+		if matchedOrigin == "" {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%s: origin %q is not allowed", name, req.Origin))
+			continue
+		}
+		if !containsString(rule.AllowedMethods, req.Method) {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%s: method %s is not allowed", name, req.Method))
+			continue
+		}
+		var denied []string
+		for _, header := range req.Headers {
+			allowed := false
+			for _, pattern := range rule.AllowedHeaders {
+				if wildcardMatch(pattern, header, true) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				denied = append(denied, header)
+			}
+		}
+		if len(denied) > 0 {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%s: headers not allowed: %s", name, strings.Join(denied, ", ")))
+			continue
+		}
+
+		result.Allowed = true
+		result.MatchedRule = i
+		result.ResponseHeaders = map[string]string{
+			"Access-Control-Allow-Origin":  req.Origin,
+			"Access-Control-Allow-Methods": strings.Join(rule.AllowedMethods, ", "),
+			"Vary":                         "Origin, Access-Control-Request-Headers, Access-Control-Request-Method",
+		}
+		if matchedOrigin == "*" {
+			result.ResponseHeaders["Access-Control-Allow-Origin"] = "*"
+		} else {
+			result.ResponseHeaders["Access-Control-Allow-Credentials"] = "true"
+		}
+		if len(req.Headers) > 0 {
+			result.ResponseHeaders["Access-Control-Allow-Headers"] = strings.Join(req.Headers, ", ")
+		}
+		if len(rule.ExposeHeaders) > 0 {
+			result.ResponseHeaders["Access-Control-Expose-Headers"] = strings.Join(rule.ExposeHeaders, ", ")
+		}
+		if rule.MaxAgeSeconds > 0 {
+			result.ResponseHeaders["Access-Control-Max-Age"] = strconv.FormatInt(rule.MaxAgeSeconds, 10)
+		}
+		return result
+	}
+	return result
+}
+
+// splitHeaderList 将逗号分隔的请求头列表拆开并去掉空白和空项
+func splitHeaderList(headers []string) []string {
+	var result []string
+	for _, entry := range headers {
+		for _, header := range strings.Split(entry, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				result = append(result, header)
+			}
+		}
+	}
+	return result
+}
+
+// wildcardMatch 匹配最多包含一个 * 的模式
+func wildcardMatch(pattern, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+	star := strings.Index(pattern, "*")
+	if star < 0 {
+		return pattern == value
+	}
+	prefix, suffix := pattern[:star], pattern[star+1:]
+	return len(value) >= len(prefix)+len(suffix) && strings.HasPrefix(value, prefix) && strings.HasSuffix(value, suffix)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package nodes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCORSRuleValidate(t *testing.T) {
+	valid := CORSRule{AllowedOrigins: []string{"https://*.example.com"}, AllowedMethods: []string{"GET", "PUT"}, AllowedHeaders: []string{"*"}}
+	tests := []struct {
+		name    string
+		edit    func(r *CORSRule)
+		wantErr string
+	}{
+		{"valid", func(r *CORSRule) {}, ""},
+		{"no origins", func(r *CORSRule) { r.AllowedOrigins = nil }, "at least one allowed origin"},
+		{"no methods", func(r *CORSRule) { r.AllowedMethods = nil }, "at least one allowed method"},
+		{"unsupported method", func(r *CORSRule) { r.AllowedMethods = []string{"PATCH"} }, `"PATCH" is not supported`},
+		{"two wildcards in origin", func(r *CORSRule) { r.AllowedOrigins = []string{"https://*.*.com"} }, "at most one * wildcard"},
+		{"empty header", func(r *CORSRule) { r.AllowedHeaders = []string{""} }, "at most one * wildcard"},
+		{"negative max age", func(r *CORSRule) { r.MaxAgeSeconds = -1 }, "max age"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := valid
+			tt.edit(&rule)
+			err := rule.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("Validate accepted the rule, want an error mentioning %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("Validate error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluateCORS(t *testing.T) {
+	rules := []CORSRule{
+		{
+			ID:             "app",
+			AllowedOrigins: []string{"https://*.example.com"},
+			AllowedMethods: []string{"GET", "PUT"},
+			AllowedHeaders: []string{"content-type", "x-amz-*"},
+			ExposeHeaders:  []string{"ETag"},
+			MaxAgeSeconds:  600,
+		},
+		{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "HEAD"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		rules       []CORSRule
+		req         CORSRequest
+		wantRule    int    // matched rule, -1 when denied
+		wantReason  string // substring of a reason, checked when denied
+		wantHeaders map[string]string
+	}{
+		{
+			name:     "origin wildcard and header wildcard",
+			req:      CORSRequest{Origin: "https://app.example.com", Method: "PUT", Headers: []string{"Content-Type", "X-Amz-Date"}},
+			wantRule: 0,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Headers":     "Content-Type, X-Amz-Date",
+				"Access-Control-Expose-Headers":    "ETag",
+				"Access-Control-Max-Age":           "600",
+			},
+		},
+		{
+			name:     "method is case-insensitive and headers may be a list",
+			req:      CORSRequest{Origin: "https://app.example.com", Method: " put ", Headers: []string{"content-type, x-amz-meta-a,"}},
+			wantRule: 0,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Headers": "content-type, x-amz-meta-a",
+			},
+		},
+		{
+			name:     "falls through to the any-origin rule",
+			req:      CORSRequest{Origin: "https://other.org", Method: "GET"},
+			wantRule: 1,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "*",
+			},
+		},
+		{
+			name:       "method not allowed by any rule",
+			req:        CORSRequest{Origin: "https://app.example.com", Method: "DELETE"},
+			wantRule:   -1,
+			wantReason: `rule "app": method DELETE is not allowed`,
+		},
+		{
+			name:       "header not allowed",
+			req:        CORSRequest{Origin: "https://app.example.com", Method: "PUT", Headers: []string{"Authorization"}},
+			wantRule:   -1,
+			wantReason: "headers not allowed: Authorization",
+		},
+		{
+			name:       "wildcard origin does not match the bare domain",
+			rules:      rules[:1],
+			req:        CORSRequest{Origin: "https://example.com", Method: "GET"},
+			wantRule:   -1,
+			wantReason: `origin "https://example.com" is not allowed`,
+		},
+		{
+			name:       "no Origin header",
+			req:        CORSRequest{Origin: " ", Method: "GET"},
+			wantRule:   -1,
+			wantReason: "not a CORS request (no Origin header)",
+		},
+		{
+			name:       "no CORS configuration",
+			rules:      []CORSRule{},
+			req:        CORSRequest{Origin: "https://app.example.com", Method: "GET"},
+			wantRule:   -1,
+			wantReason: "no CORS configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rules
+			if tt.rules != nil {
+				r = tt.rules
+			}
+			got := EvaluateCORS(r, tt.req)
+			if got.MatchedRule != tt.wantRule || got.Allowed != (tt.wantRule >= 0) {
+				t.Fatalf("got allowed=%v rule %d (%q), want rule %d", got.Allowed, got.MatchedRule, got.Reasons, tt.wantRule)
+			}
+			if tt.wantRule < 0 {
+				if !strings.Contains(strings.Join(got.Reasons, "\n"), tt.wantReason) {
+					t.Errorf("reasons %q do not mention %q", got.Reasons, tt.wantReason)
+				}
+				return
+			}
+			for k, v := range tt.wantHeaders {
+				if got.ResponseHeaders[k] != v {
+					t.Errorf("%s = %q, want %q", k, got.ResponseHeaders[k], v)
+				}
+			}
+		})
+	}
+
+	// Without an Origin no rule is consulted, not even an any-origin rule
+	if got := EvaluateCORS(rules, CORSRequest{Method: "GET"}); len(got.Reasons) != 1 {
+		t.Errorf("request without Origin was matched against rules: %q", got.Reasons)
+	}
+}
@@ -0,0 +1,31 @@
+package nodes
+
+import "fmt"
+
+// 默认加密算法
+const (
+	SSEAlgorithmAES256  = "AES256"       // SSE-S3
+	SSEAlgorithmKMS     = "aws:kms"      // SSE-KMS
+	SSEAlgorithmKMSDSSE = "aws:kms:dsse" // 双层 SSE-KMS
+)
+
+// EncryptionRule 桶默认加密规则
+type EncryptionRule struct {
+	Algorithm        string `json:"algorithm"`        // AES256 / aws:kms / aws:kms:dsse
+	KMSKeyID         string `json:"kmsKeyId"`         // KMS 密钥 ID 或 ARN，为空时使用 AWS 托管密钥
+	BucketKeyEnabled bool   `json:"bucketKeyEnabled"` // 是否启用 S3 桶密钥
+}
+
+// Validate 检查加密规则
+func (r EncryptionRule) Validate() error {
+	switch r.Algorithm {
+	case SSEAlgorithmAES256:
+		if r.KMSKeyID != "" {
+			return fmt.Errorf("a KMS key can only be used with %s or %s", SSEAlgorithmKMS, SSEAlgorithmKMSDSSE)
+		}
+	case SSEAlgorithmKMS, SSEAlgorithmKMSDSSE:
+	default:
+		return fmt.Errorf("encryption algorithm must be %s, %s or %s", SSEAlgorithmAES256, SSEAlgorithmKMS, SSEAlgorithmKMSDSSE)
+	}
+	return nil
+}
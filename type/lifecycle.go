@@ -0,0 +1,146 @@
+package nodes
+
+import (
+	"fmt"
+	"time"
+)
+
+// 生命周期规则状态
+const (
+	RuleEnabled  = "Enabled"
+	RuleDisabled = "Disabled"
+)
+
+// LifecycleDateLayout 生命周期规则中日期字段的格式
+const LifecycleDateLayout = "2006-01-02"
+
+// LifecycleFilter 规则作用的对象范围，所有条件同时满足才生效，全部为空表示整个桶
+type LifecycleFilter struct {
+	Prefix                string `json:"prefix"`                // 对象键前缀
+	Tags                  []Tag  `json:"tags"`                  // 对象标签
+	ObjectSizeGreaterThan int64  `json:"objectSizeGreaterThan"` // 对象大小下限(字节)，0 表示不限
+	ObjectSizeLessThan    int64  `json:"objectSizeLessThan"`    // 对象大小上限(字节)，0 表示不限
+}
+
+// LifecycleExpiration 当前版本过期设置，Days 与 Date 二选一
+type LifecycleExpiration struct {
+	Days                      int64  `json:"days"`                      // 创建后多少天过期
+	Date                      string `json:"date"`                      // 过期日期(YYYY-MM-DD)
+	ExpiredObjectDeleteMarker bool   `json:"expiredObjectDeleteMarker"` // 删除过期的删除标记
+}
+
+// LifecycleNoncurrentExpiration 非当前版本过期设置
+type LifecycleNoncurrentExpiration struct {
+	NoncurrentDays          int64 `json:"noncurrentDays"`          // 成为非当前版本多少天后删除
+	NewerNoncurrentVersions int64 `json:"newerNoncurrentVersions"` // 保留的较新非当前版本数量
+}
+
+// LifecycleTransition 存储类型转换，Days 与 Date 二选一
+type LifecycleTransition struct {
+	Days         int64  `json:"days"`         // 创建后多少天转换，0 表示立即转换
+	Date         string `json:"date"`         // 转换日期(YYYY-MM-DD)
+	StorageClass string `json:"storageClass"` // 目标存储类型
+}
+
+// LifecycleNoncurrentTransition 非当前版本存储类型转换
+type LifecycleNoncurrentTransition struct {
+	NoncurrentDays          int64  `json:"noncurrentDays"`          // 成为非当前版本多少天后转换
+	NewerNoncurrentVersions int64  `json:"newerNoncurrentVersions"` // 保留的较新非当前版本数量
+	StorageClass            string `json:"storageClass"`            // 目标存储类型
+}
+
+// LifecycleRule 生命周期规则
+type LifecycleRule struct {
+	ID                           string                          `json:"id"`                           // 规则 ID，桶内唯一
+	Status                       string                          `json:"status"`                       // Enabled / Disabled
+	Filter                       LifecycleFilter                 `json:"filter"`                       // 作用范围
+	Expiration                   *LifecycleExpiration            `json:"expiration"`                   // 当前版本过期
+	NoncurrentExpiration         *LifecycleNoncurrentExpiration  `json:"noncurrentExpiration"`         // 非当前版本过期
+	Transitions                  []LifecycleTransition           `json:"transitions"`                  // 当前版本转换
+	NoncurrentTransitions        []LifecycleNoncurrentTransition `json:"noncurrentTransitions"`        // 非当前版本转换
+	AbortIncompleteMultipartDays int64                           `json:"abortIncompleteMultipartDays"` // 未完成分片上传多少天后中止，0 表示不中止
+}
+
+// Validate 检查规则是否能被 PutBucketLifecycleConfiguration 接受
+func (r LifecycleRule) Validate() error {
+	if r.ID == "" || len(r.ID) > 255 {
+		return fmt.Errorf("rule ID must be between 1 and 255 characters long")
+	}
+	if r.Status != RuleEnabled && r.Status != RuleDisabled {
+		return fmt.Errorf("rule %s: status must be %s or %s", r.ID, RuleEnabled, RuleDisabled)
+	}
+	if r.Filter.ObjectSizeGreaterThan < 0 || r.Filter.ObjectSizeLessThan < 0 ||
+		(r.Filter.ObjectSizeLessThan > 0 && r.Filter.ObjectSizeLessThan <= r.Filter.ObjectSizeGreaterThan) {
+		return fmt.Errorf("rule %s: invalid object size range", r.ID)
+	}
+	for _, tag := range r.Filter.Tags {
+		if tag.Key == "" {
+			return fmt.Errorf("rule %s: filter tag key must not be empty", r.ID)
+		}
+	}
+	if r.Expiration == nil && r.NoncurrentExpiration == nil && len(r.Transitions) == 0 &&
+		len(r.NoncurrentTransitions) == 0 && r.AbortIncompleteMultipartDays == 0 {
+		return fmt.Errorf("rule %s: at least one action is required", r.ID)
+	}
+
+	if e := r.Expiration; e != nil {
+		set := 0
+		if e.Days != 0 {
+			set++
+		}
+		if e.Date != "" {
+			set++
+		}
+		if e.ExpiredObjectDeleteMarker {
+			set++
+		}
+		if set != 1 {
+			return fmt.Errorf("rule %s: expiration needs exactly one of days, date or expired delete marker", r.ID)
+		}
+		if e.ExpiredObjectDeleteMarker && len(r.Filter.Tags) > 0 {
+			return fmt.Errorf("rule %s: expired delete marker cleanup cannot be combined with a tag filter", r.ID)
+		}
+		if err := checkDaysOrDate(e.Days, e.Date); err != nil {
+			return fmt.Errorf("rule %s: expiration %v", r.ID, err)
+		}
+	}
+	if n := r.NoncurrentExpiration; n != nil && (n.NoncurrentDays <= 0 || n.NewerNoncurrentVersions < 0) {
+		return fmt.Errorf("rule %s: noncurrent expiration needs a positive number of days", r.ID)
+	}
+	for _, t := range r.Transitions {
+		if t.StorageClass == "" {
+			return fmt.Errorf("rule %s: transition storage class must not be empty", r.ID)
+		}
+		// 未设置 Date 时 Days 可以为 0，表示立即转换(如转到 INTELLIGENT_TIERING)
+		if t.Days != 0 && t.Date != "" {
+			return fmt.Errorf("rule %s: transition needs exactly one of days or date", r.ID)
+		}
+		if err := checkDaysOrDate(t.Days, t.Date); err != nil {
+			return fmt.Errorf("rule %s: transition %v", r.ID, err)
+		}
+	}
+	for _, t := range r.NoncurrentTransitions {
+		if t.StorageClass == "" || t.NoncurrentDays <= 0 || t.NewerNoncurrentVersions < 0 {
+			return fmt.Errorf("rule %s: noncurrent transition needs a storage class and a positive number of days", r.ID)
+		}
+	}
+	if r.AbortIncompleteMultipartDays < 0 {
+		return fmt.Errorf("rule %s: abort incomplete multipart days must not be negative", r.ID)
+	}
+	if r.AbortIncompleteMultipartDays > 0 && len(r.Filter.Tags) > 0 {
+		return fmt.Errorf("rule %s: aborting incomplete multipart uploads cannot be combined with a tag filter", r.ID)
+	}
+	return nil
+}
+
+func checkDaysOrDate(days int64, date string) error {
+	if days < 0 {
+		return fmt.Errorf("days must not be negative")
+	}
+	if date != "" {
+		if _, err := time.Parse(LifecycleDateLayout, date); err != nil {
+			return fmt.Errorf("date %q must be formatted as YYYY-MM-DD", date)
+		}
+	}
+	return nil
+}
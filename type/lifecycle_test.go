@@ -0,0 +1,86 @@
+package nodes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLifecycleRuleValidate(t *testing.T) {
+	rule := func(edit func(r *LifecycleRule)) LifecycleRule {
+		r := LifecycleRule{ID: "archive", Status: RuleEnabled}
+		edit(&r)
+		return r
+	}
+	transition := func(days int64, date, class string) func(r *LifecycleRule) {
+		return func(r *LifecycleRule) {
+			r.Transitions = []LifecycleTransition{{Days: days, Date: date, StorageClass: class}}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		rule    LifecycleRule
+		wantErr string // substring of the expected error, empty when valid
+	}{
+		{"expire after days", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{Days: 30} }), ""},
+		{"expire on date", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{Date: "2030-01-01"} }), ""},
+		{"expired delete markers", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{ExpiredObjectDeleteMarker: true} }), ""},
+		{"transition after days", rule(transition(30, "", "GLACIER")), ""},
+		{"transition on date", rule(transition(0, "2030-01-01", "GLACIER")), ""},
+		{"immediate transition", rule(transition(0, "", "INTELLIGENT_TIERING")), ""},
+		{"noncurrent versions", rule(func(r *LifecycleRule) {
+			r.NoncurrentExpiration = &LifecycleNoncurrentExpiration{NoncurrentDays: 90, NewerNoncurrentVersions: 3}
+			r.NoncurrentTransitions = []LifecycleNoncurrentTransition{{NoncurrentDays: 30, StorageClass: "STANDARD_IA"}}
+		}), ""},
+		{"abort multipart with size filter", rule(func(r *LifecycleRule) {
+			r.AbortIncompleteMultipartDays = 7
+			r.Filter = LifecycleFilter{Prefix: "uploads/", ObjectSizeGreaterThan: 1024, ObjectSizeLessThan: 4096}
+		}), ""},
+
+		{"missing ID", rule(func(r *LifecycleRule) { r.ID = ""; r.AbortIncompleteMultipartDays = 1 }), "between 1 and 255"},
+		{"bad status", rule(func(r *LifecycleRule) { r.Status = "enabled"; r.AbortIncompleteMultipartDays = 1 }), "status must be"},
+		{"no action", rule(func(r *LifecycleRule) {}), "at least one action"},
+		{"inverted size range", rule(func(r *LifecycleRule) {
+			r.Filter.ObjectSizeGreaterThan, r.Filter.ObjectSizeLessThan = 4096, 1024
+			r.AbortIncompleteMultipartDays = 1
+		}), "invalid object size range"},
+		{"empty tag key", rule(func(r *LifecycleRule) {
+			r.Filter.Tags = []Tag{{Key: "", Value: "x"}}
+			r.Expiration = &LifecycleExpiration{Days: 1}
+		}), "tag key must not be empty"},
+		{"expiration without days", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{} }), "exactly one of days, date"},
+		{"expiration with days and date", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{Days: 1, Date: "2030-01-01"} }), "exactly one of days, date"},
+		{"expiration with bad date", rule(func(r *LifecycleRule) { r.Expiration = &LifecycleExpiration{Date: "01/01/2030"} }), "YYYY-MM-DD"},
+		{"delete markers with tag filter", rule(func(r *LifecycleRule) {
+			r.Filter.Tags = []Tag{{Key: "k", Value: "v"}}
+			r.Expiration = &LifecycleExpiration{ExpiredObjectDeleteMarker: true}
+		}), "cannot be combined with a tag filter"},
+		{"transition without class", rule(transition(30, "", "")), "storage class must not be empty"},
+		{"transition with days and date", rule(transition(30, "2030-01-01", "GLACIER")), "exactly one of days or date"},
+		{"transition with negative days", rule(transition(-1, "", "GLACIER")), "must not be negative"},
+		{"noncurrent expiration without days", rule(func(r *LifecycleRule) {
+			r.NoncurrentExpiration = &LifecycleNoncurrentExpiration{}
+		}), "positive number of days"},
+		{"noncurrent transition without days", rule(func(r *LifecycleRule) {
+			r.NoncurrentTransitions = []LifecycleNoncurrentTransition{{StorageClass: "GLACIER"}}
+		}), "positive number of days"},
+		{"abort multipart with tag filter", rule(func(r *LifecycleRule) {
+			r.Filter.Tags = []Tag{{Key: "k", Value: "v"}}
+			r.AbortIncompleteMultipartDays = 7
+		}), "cannot be combined with a tag filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("Validate accepted the rule, want an error mentioning %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("Validate error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package nodes
+
+// LoggingConfig 桶的服务器访问日志配置
+type LoggingConfig struct {
+	Enabled      bool   `json:"enabled"`      // 是否启用访问日志
+	TargetBucket string `json:"targetBucket"` // 日志写入的目标桶
+	TargetPrefix string `json:"targetPrefix"` // 日志对象键前缀，如 logs/
+}
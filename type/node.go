@@ -1,15 +1,80 @@
 // ./nodes/node.go
 package nodes
 
-import "encoding/json"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 寻址方式
+const (
+	AddressingPath    = "path"    // 路径风格: endpoint/bucket/key
+	AddressingVirtual = "virtual" // 虚拟主机风格: bucket.endpoint/key
+)
+
+// 凭证来源
+const (
+	CredentialStatic  = "static"  // 使用节点中保存的 AccessKey/SecretKey(及可选的 SessionToken)
+	CredentialEnv     = "env"     // 使用 AWS_ACCESS_KEY_ID 等环境变量
+	CredentialProfile = "profile" // 使用共享凭证文件(~/.aws/credentials)中的 profile
+	CredentialProcess = "process" // 运行外部 credential_process 命令获取凭证
+)
 
 // Node 结构体定义JSON中的节点信息
 type Node struct {
+	ID        string `json:"ID"` // 稳定标识，创建时生成，不随名称或顺序变化
 	NodeName  string `json:"NodeName"`
 	EndPoint  string `json:"EndPoint"`
 	AccessKey string `json:"AccessKey"`
 	SecretKey string `json:"SecretKey"`
 	Region    string `json:"Region"`
+
+	// 连接选项，均可省略，省略时使用默认值
+	AddressingStyle string `json:"AddressingStyle,omitempty"` // 寻址方式，默认 path
+	DisableSSL      bool   `json:"DisableSSL,omitempty"`      // 使用 HTTP 而非 HTTPS
+	CABundle        string `json:"CABundle,omitempty"`        // 自定义 CA 证书文件路径(PEM)
+	SkipTLSVerify   bool   `json:"SkipTLSVerify,omitempty"`   // 跳过 TLS 证书校验
+	Proxy           string `json:"Proxy,omitempty"`           // HTTP(S) 代理地址
+	ConnectTimeout  int    `json:"ConnectTimeout,omitempty"`  // 连接超时(秒)，0 表示不限制
+	ReadTimeout     int    `json:"ReadTimeout,omitempty"`     // 读取响应头超时(秒)，0 表示不限制
+
+	// 凭证来源，省略时为 static
+	CredentialSource  string `json:"CredentialSource,omitempty"`  // static / env / profile / process
+	SessionToken      string `json:"SessionToken,omitempty"`      // static 来源的临时凭证(STS)会话令牌
+	Profile           string `json:"Profile,omitempty"`           // profile 来源使用的 profile 名称
+	CredentialsFile   string `json:"CredentialsFile,omitempty"`   // profile 来源的共享凭证文件，省略时使用默认位置
+	CredentialProcess string `json:"CredentialProcess,omitempty"` // process 来源执行的命令
+
+	// AssumeRole，设置 RoleARN 后以上述凭证为基础身份扮演该角色
+	RoleARN         string `json:"RoleARN,omitempty"`         // 要扮演的角色 ARN
+	ExternalID      string `json:"ExternalID,omitempty"`      // 角色信任策略要求的 ExternalId
+	RoleSessionName string `json:"RoleSessionName,omitempty"` // 角色会话名称，省略时为 srsc-client
+	RoleDuration    int    `json:"RoleDuration,omitempty"`    // 角色会话时长(秒)，0 表示 STS 默认的 15 分钟
+	STSEndpoint     string `json:"STSEndpoint,omitempty"`     // STS 端点，省略时使用区域默认端点
+}
+
+// UsePathStyle 是否使用路径风格寻址
+func (n Node) UsePathStyle() bool {
+	return n.AddressingStyle != AddressingVirtual
+}
+
+// Source 返回节点的凭证来源，未设置时为 static
+func (n Node) Source() string {
+	if n.CredentialSource == "" {
+		return CredentialStatic
+	}
+	return n.CredentialSource
+}
+
+// Redacted 返回去掉密钥的副本，用于返回给前端
+func (n Node) Redacted() Node {
+	n.SecretKey = ""
+	n.SessionToken = ""
+	return n
 }
 
 // GetNodes 从JSON内容解析所有节点
@@ -25,3 +90,105 @@ func AddNode(nodes []Node, node Node) []Node {
 	nodes = append(nodes, node)
 	return nodes
 }
+
+// ErrNodeNotFound 指定 ID 的节点不存在
+var ErrNodeNotFound = errors.New("node not found")
+
+// NewID 生成新的节点 ID
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// EnsureIDs 为缺少 ID 的节点(旧版配置)补充 ID，返回是否有修改
+func EnsureIDs(nodes []Node) bool {
+	changed := false
+	for i := range nodes {
+		if nodes[i].ID == "" {
+			nodes[i].ID = NewID()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// FindNode 返回指定 ID 节点的下标，不存在时返回 -1
+func FindNode(nodes []Node, id string) int {
+	for i, node := range nodes {
+		if node.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// CheckName 检查名称非空且不与除 id 之外的节点重名(忽略大小写和首尾空格)
+func CheckName(nodes []Node, id, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("node name must not be empty")
+	}
+	for _, node := range nodes {
+		if node.ID != id && strings.EqualFold(strings.TrimSpace(node.NodeName), name) {
+			return fmt.Errorf("a node named %q already exists", name)
+		}
+	}
+	return nil
+}
+
+// UpdateNode 用 node 替换同 ID 的节点，位置不变
+func UpdateNode(nodes []Node, node Node) ([]Node, error) {
+	i := FindNode(nodes, node.ID)
+	if i < 0 {
+		return nil, ErrNodeNotFound
+	}
+	node.NodeName = strings.TrimSpace(node.NodeName)
+	if err := CheckName(nodes, node.ID, node.NodeName); err != nil {
+		return nil, err
+	}
+	nodes[i] = node
+	return nodes, nil
+}
+
+// RenameNode 修改指定节点的名称
+func RenameNode(nodes []Node, id, name string) ([]Node, error) {
+	i := FindNode(nodes, id)
+	if i < 0 {
+		return nil, ErrNodeNotFound
+	}
+	if err := CheckName(nodes, id, name); err != nil {
+		return nil, err
+	}
+	nodes[i].NodeName = strings.TrimSpace(name)
+	return nodes, nil
+}
+
+// DeleteNode 删除指定节点
+func DeleteNode(nodes []Node, id string) ([]Node, error) {
+	i := FindNode(nodes, id)
+	if i < 0 {
+		return nil, ErrNodeNotFound
+	}
+	return append(nodes[:i], nodes[i+1:]...), nil
+}
+
+// MoveNode 将指定节点移动到 index 位置，index 超出范围时移动到首/尾
+func MoveNode(nodes []Node, id string, index int) ([]Node, error) {
+	i := FindNode(nodes, id)
+	if i < 0 {
+		return nil, ErrNodeNotFound
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index > len(nodes)-1 {
+		index = len(nodes) - 1
+	}
+	node := nodes[i]
+	nodes = append(nodes[:i], nodes[i+1:]...)
+	nodes = append(nodes[:index], append([]Node{node}, nodes[index:]...)...)
+	return nodes, nil
+}
@@ -0,0 +1,117 @@
+package nodes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 通知目标类型
+const (
+	NotifyQueue  = "queue"  // SQS 队列，MinIO 的 webhook/kafka 等目标也以队列 ARN 表示
+	NotifyTopic  = "topic"  // SNS 主题
+	NotifyLambda = "lambda" // Lambda 函数
+)
+
+// notificationEventFamilies 支持的事件类别，事件名形如 s3:ObjectCreated:Put 或 s3:ObjectCreated:*，
+// 只有 s3:ReducedRedundancyLostObject 不分子类
+var notificationEventFamilies = map[string]bool{
+	"ObjectCreated": true, "ObjectRemoved": true, "ObjectRestore": true, "ObjectAccessed": true,
+	"ObjectTagging": true, "ObjectAcl": true, "Replication": true, "LifecycleExpiration": true,
+	"LifecycleTransition": true, "IntelligentTiering": true, "ReducedRedundancyLostObject": true,
+}
+
+// NotificationRule 一条事件通知配置
+type NotificationRule struct {
+	ID        string   `json:"id"`        // 配置 ID，可为空
+	Type      string   `json:"type"`      // queue / topic / lambda
+	TargetARN string   `json:"targetArn"` // 目标 ARN
+	Events    []string `json:"events"`    // 事件，如 s3:ObjectCreated:*
+	Prefix    string   `json:"prefix"`    // 对象键前缀过滤
+	Suffix    string   `json:"suffix"`    // 对象键后缀过滤
+}
+
+// NotificationConfig 桶的事件通知配置
+type NotificationConfig struct {
+	Rules       []NotificationRule `json:"rules"`       // 通知规则
+	EventBridge bool               `json:"eventBridge"` // 是否同时发送到 Amazon EventBridge
+}
+
+// ValidateNotification 检查通知配置，返回发现的问题，没有问题时返回空切片
+func ValidateNotification(config NotificationConfig) []string {
+	problems := []string{}
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	ids := make(map[string]bool)
+	for i, rule := range config.Rules {
+		name := rule.name(i)
+		if rule.ID != "" {
+			if ids[rule.ID] {
+				add("%s: duplicate ID", name)
+			}
+			ids[rule.ID] = true
+		}
+
+		service := map[string]string{NotifyQueue: "sqs", NotifyTopic: "sns", NotifyLambda: "lambda"}[rule.Type]
+		if service == "" {
+			add("%s: type must be %s, %s or %s", name, NotifyQueue, NotifyTopic, NotifyLambda)
+		} else if parts := strings.SplitN(rule.TargetARN, ":", 6); len(parts) < 6 || parts[0] != "arn" || parts[2] != service {
+			add("%s: target %q is not a %s ARN like arn:aws:%s:region:account:name", name, rule.TargetARN, service, service)
+		}
+
+		if len(rule.Events) == 0 {
+			add("%s: at least one event is required", name)
+		}
+		for _, event := range rule.Events {
+			if !validEvent(event) {
+				add("%s: unknown event %q", name, event)
+			}
+		}
+	}
+
+	// S3 拒绝同一事件上前缀和后缀过滤重叠的配置
+	for i := range config.Rules {
+		for j := i + 1; j < len(config.Rules); j++ {
+			a, b := config.Rules[i], config.Rules[j]
+			if eventsOverlap(a.Events, b.Events) &&
+				(strings.HasPrefix(a.Prefix, b.Prefix) || strings.HasPrefix(b.Prefix, a.Prefix)) &&
+				(strings.HasSuffix(a.Suffix, b.Suffix) || strings.HasSuffix(b.Suffix, a.Suffix)) {
+				add("%s and %s: filters overlap for the same event type", a.name(i), b.name(j))
+			}
+		}
+	}
+	return problems
+}
+
+func (r NotificationRule) name(i int) string {
+	if r.ID != "" {
+		return fmt.Sprintf("rule %q", r.ID)
+	}
+	return fmt.Sprintf("rule %d", i+1)
+}
+
+// validEvent 检查事件名是否为 s3:<类别>:<名称或 *>；
+// 只有 s3:ReducedRedundancyLostObject 没有第三段
+func validEvent(event string) bool {
+	parts := strings.Split(event, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "s3" || !notificationEventFamilies[parts[1]] {
+		return false
+	}
+	if parts[1] == "ReducedRedundancyLostObject" {
+		return len(parts) == 2
+	}
+	return len(parts) == 3 && parts[2] != ""
+}
+
+// eventsOverlap 判断两组事件是否有交集，s3:ObjectCreated:* 与 s3:ObjectCreated:Put 视为相交
+func eventsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y || wildcardMatch(x, y, false) || wildcardMatch(y, x, false) {
+				return true
+			}
+		}
+	}
+	return false
+}
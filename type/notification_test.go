@@ -0,0 +1,86 @@
+package nodes
+
+import (
+	"strings"
+	"testing"
+)
+
+func queueRule(id string, events ...string) NotificationRule {
+	return NotificationRule{
+		ID:        id,
+		Type:      NotifyQueue,
+		TargetARN: "arn:aws:sqs:us-east-1:123456789012:events",
+		Events:    events,
+	}
+}
+
+func TestValidateNotification(t *testing.T) {
+	withFilter := func(r NotificationRule, prefix, suffix string) NotificationRule {
+		r.Prefix, r.Suffix = prefix, suffix
+		return r
+	}
+
+	tests := []struct {
+		name  string
+		rules []NotificationRule
+		want  []string // substrings of the expected problems, empty when valid
+	}{
+		{"wildcard event", []NotificationRule{queueRule("a", "s3:ObjectCreated:*")}, nil},
+		{"concrete events", []NotificationRule{queueRule("a", "s3:ObjectCreated:Put", "s3:ObjectRemoved:Delete")}, nil},
+		{"reduced redundancy", []NotificationRule{queueRule("a", "s3:ReducedRedundancyLostObject")}, nil},
+		{"topic and lambda", []NotificationRule{
+			{Type: NotifyTopic, TargetARN: "arn:aws:sns:us-east-1:123456789012:t", Events: []string{"s3:ObjectRestore:Completed"}},
+			{Type: NotifyLambda, TargetARN: "arn:aws:lambda:us-east-1:123456789012:function:f", Events: []string{"s3:ObjectTagging:*"}},
+		}, nil},
+
+		{"family without type", []NotificationRule{queueRule("a", "s3:ObjectCreated")}, []string{`unknown event "s3:ObjectCreated"`}},
+		{"empty type", []NotificationRule{queueRule("a", "s3:ObjectRemoved:")}, []string{`unknown event "s3:ObjectRemoved:"`}},
+		{"reduced redundancy with type", []NotificationRule{queueRule("a", "s3:ReducedRedundancyLostObject:*")}, []string{"unknown event"}},
+		{"unknown family", []NotificationRule{queueRule("a", "s3:ObjectMoved:*")}, []string{"unknown event"}},
+		{"not s3", []NotificationRule{queueRule("a", "sqs:ObjectCreated:*")}, []string{"unknown event"}},
+		{"no events", []NotificationRule{queueRule("a")}, []string{"at least one event"}},
+		{"unknown type", []NotificationRule{{Type: "webhook", TargetARN: "arn:aws:sqs:us-east-1:1:q", Events: []string{"s3:ObjectCreated:*"}}},
+			[]string{"type must be"}},
+		{"target of another service", []NotificationRule{{Type: NotifyTopic, TargetARN: "arn:aws:sqs:us-east-1:1:q", Events: []string{"s3:ObjectCreated:*"}}},
+			[]string{"not a sns ARN"}},
+		{"duplicate ID", []NotificationRule{
+			withFilter(queueRule("a", "s3:ObjectCreated:*"), "images/", ""),
+			withFilter(queueRule("a", "s3:ObjectCreated:*"), "docs/", ""),
+		}, []string{`rule "a": duplicate ID`}},
+
+		{"wildcard overlaps concrete event", []NotificationRule{
+			queueRule("a", "s3:ObjectCreated:*"),
+			queueRule("b", "s3:ObjectCreated:Put"),
+		}, []string{`rule "a" and rule "b": filters overlap`}},
+		{"nested prefixes overlap", []NotificationRule{
+			withFilter(queueRule("a", "s3:ObjectRemoved:*"), "logs/", ""),
+			withFilter(queueRule("b", "s3:ObjectRemoved:Delete"), "logs/2024/", ""),
+		}, []string{"filters overlap"}},
+		{"disjoint prefixes", []NotificationRule{
+			withFilter(queueRule("a", "s3:ObjectCreated:*"), "images/", ""),
+			withFilter(queueRule("b", "s3:ObjectCreated:*"), "docs/", ""),
+		}, nil},
+		{"disjoint suffixes", []NotificationRule{
+			withFilter(queueRule("a", "s3:ObjectCreated:*"), "", ".jpg"),
+			withFilter(queueRule("b", "s3:ObjectCreated:*"), "", ".png"),
+		}, nil},
+		{"different events", []NotificationRule{
+			queueRule("a", "s3:ObjectCreated:*"),
+			queueRule("b", "s3:ObjectRemoved:*"),
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := ValidateNotification(NotificationConfig{Rules: tt.rules})
+			if len(problems) != len(tt.want) {
+				t.Fatalf("got problems %q, want %d matching %q", problems, len(tt.want), tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %q does not mention %q", problems[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package nodes
+
+import (
+	"fmt"
+	"time"
+)
+
+// 对象锁保留模式
+const (
+	RetentionGovernance = "GOVERNANCE"
+	RetentionCompliance = "COMPLIANCE"
+)
+
+// ObjectLockConfig 桶的对象锁配置
+type ObjectLockConfig struct {
+	Enabled bool   `json:"enabled"` // 桶是否启用对象锁
+	Mode    string `json:"mode"`    // 默认保留模式: GOVERNANCE / COMPLIANCE，为空表示没有默认保留
+	Days    int64  `json:"days"`    // 默认保留天数，与 Years 二选一
+	Years   int64  `json:"years"`   // 默认保留年数，与 Days 二选一
+}
+
+// Validate 检查对象锁配置
+func (c ObjectLockConfig) Validate() error {
+	if !c.Enabled {
+		return fmt.Errorf("object lock cannot be disabled once enabled")
+	}
+	if c.Mode == "" {
+		if c.Days != 0 || c.Years != 0 {
+			return fmt.Errorf("default retention period needs a retention mode")
+		}
+		return nil
+	}
+	if err := checkRetentionMode(c.Mode); err != nil {
+		return err
+	}
+	if (c.Days > 0) == (c.Years > 0) || c.Days < 0 || c.Years < 0 {
+		return fmt.Errorf("default retention needs exactly one positive value of days or years")
+	}
+	return nil
+}
+
+// ObjectRetention 对象版本的保留设置
+type ObjectRetention struct {
+	Mode            string    `json:"mode"`            // GOVERNANCE / COMPLIANCE，为空表示没有保留
+	RetainUntilDate time.Time `json:"retainUntilDate"` // 保留截止时间
+}
+
+// IsZero 是否为空的保留设置，写入空设置表示移除保留
+func (r ObjectRetention) IsZero() bool {
+	return r.Mode == "" && r.RetainUntilDate.IsZero()
+}
+
+// Validate 检查保留设置
+func (r ObjectRetention) Validate() error {
+	if err := checkRetentionMode(r.Mode); err != nil {
+		return err
+	}
+	if !r.RetainUntilDate.After(time.Now()) {
+		return fmt.Errorf("retain-until date must be in the future")
+	}
+	return nil
+}
+
+func checkRetentionMode(mode string) error {
+	if mode != RetentionGovernance && mode != RetentionCompliance {
+		return fmt.Errorf("retention mode must be %s or %s", RetentionGovernance, RetentionCompliance)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package nodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	policyActionPattern = regexp.MustCompile(`^[a-zA-Z0-9-]+:[A-Za-z*?]+$`)
+	accountIDPattern    = regexp.MustCompile(`^\d{12}$`)
+	iamARNPattern       = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:(iam|sts)::(\d{12}|\*):.+$`)
+	s3ARNPattern        = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:s3:::([^/]+)(/.*)?$`)
+	canonicalIDPattern  = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	servicePattern      = regexp.MustCompile(`^[a-z0-9.-]+\.amazonaws\.com(\.cn)?$`)
+)
+
+// ValidatePolicy 在上传前本地检查桶策略的结构、Action、Resource ARN 和 Principal 格式，
+// 返回发现的问题，没有问题时返回空切片
+func ValidatePolicy(bucketName, document string) []string {
+	problems := []string{}
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	var policy map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		add("policy is not a valid JSON object: %v", err)
+		return problems
+	}
+	for key := range policy {
+		if key != "Version" && key != "Id" && key != "Statement" {
+			add("unknown top-level element %q", key)
+		}
+	}
+	if raw, ok := policy["Version"]; ok {
+		var version string
+		if json.Unmarshal(raw, &version) != nil || (version != "2012-10-17" && version != "2008-10-17") {
+			add("Version must be \"2012-10-17\" or \"2008-10-17\"")
+		}
+	}
+
+	raw, ok := policy["Statement"]
+	if !ok {
+		add("policy has no Statement")
+		return problems
+	}
+	var statements []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &statements); err != nil {
+		var single map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &single); err != nil {
+			add("Statement must be an object or an array of objects")
+			return problems
+		}
+		statements = []map[string]json.RawMessage{single}
+	}
+	if len(statements) == 0 {
+		add("Statement must not be empty")
+	}
+
+	for i, statement := range statements {
+		name := fmt.Sprintf("statement %d", i+1)
+		if raw, ok := statement["Sid"]; ok {
+			var sid string
+			if json.Unmarshal(raw, &sid) == nil && sid != "" {
+				name = fmt.Sprintf("statement %q", sid)
+			}
+		}
+		for _, problem := range validateStatement(bucketName, statement) {
+			add("%s: %s", name, problem)
+		}
+	}
+	return problems
+}
+
+// validateStatement 检查单条语句
+func validateStatement(bucketName string, statement map[string]json.RawMessage) []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	known := map[string]bool{"Sid": true, "Effect": true, "Principal": true, "NotPrincipal": true,
+		"Action": true, "NotAction": true, "Resource": true, "NotResource": true, "Condition": true}
+	for key := range statement {
+		if !known[key] {
+			add("unknown element %q", key)
+		}
+	}
+
+	var effect string
+	if raw, ok := statement["Effect"]; !ok || json.Unmarshal(raw, &effect) != nil || (effect != "Allow" && effect != "Deny") {
+		add("Effect must be \"Allow\" or \"Deny\"")
+	}
+
+	principalKey, principal, err := exactlyOne(statement, "Principal", "NotPrincipal")
+	if err != nil {
+		add("%v", err)
+	} else {
+		problems = append(problems, validatePrincipal(principalKey, principal)...)
+	}
+
+	actionKey, actionRaw, err := exactlyOne(statement, "Action", "NotAction")
+	var actions []string
+	if err != nil {
+		add("%v", err)
+	} else if actions, err = stringOrList(actionRaw); err != nil {
+		add("%s %v", actionKey, err)
+	} else {
+		for _, action := range actions {
+			if action == "*" {
+				continue
+			}
+			if !policyActionPattern.MatchString(action) {
+				add("%s %q is not of the form \"s3:ActionName\"", actionKey, action)
+			} else if !strings.HasPrefix(strings.ToLower(action), "s3:") {
+				add("%s %q is not an S3 action; bucket policies only support s3 actions", actionKey, action)
+			}
+		}
+	}
+
+	resourceKey, resourceRaw, err := exactlyOne(statement, "Resource", "NotResource")
+	if err != nil {
+		add("%v", err)
+	} else if resources, err := stringOrList(resourceRaw); err != nil {
+		add("%s %v", resourceKey, err)
+	} else {
+		for _, resource := range resources {
+			problems = append(problems, validateResource(bucketName, resourceKey, resource)...)
+		}
+	}
+
+	if raw, ok := statement["Condition"]; ok {
+		var condition map[string]map[string]json.RawMessage
+		if json.Unmarshal(raw, &condition) != nil {
+			add("Condition must map operators to objects of condition keys")
+		}
+	}
+
+	// 防止误把所有人(包括自己)拒之门外，Action 名称不区分大小写
+	lowerActions := make([]string, len(actions))
+	for i, action := range actions {
+		lowerActions[i] = strings.ToLower(action)
+	}
+	if effect == "Deny" && principalKey == "Principal" && isWildcardPrincipal(principal) &&
+		actionKey == "Action" && containsAny(lowerActions, "*", "s3:*") && statement["Condition"] == nil {
+		add("denies every action to everyone without a Condition; this would lock you out of the bucket")
+	}
+	return problems
+}
+
+// validatePrincipal 检查 Principal/NotPrincipal 格式
+func validatePrincipal(key string, raw json.RawMessage) []string {
+	var problems []string
+	if isWildcardPrincipal(raw) {
+		return nil
+	}
+	var principal map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &principal); err != nil {
+		return []string{fmt.Sprintf("%s must be \"*\" or an object such as {\"AWS\": ...}", key)}
+	}
+	for kind, valuesRaw := range principal {
+		values, err := stringOrList(valuesRaw)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s.%s %v", key, kind, err))
+			continue
+		}
+		for _, value := range values {
+			valid := true
+			switch kind {
+			case "AWS":
+				valid = value == "*" || accountIDPattern.MatchString(value) || iamARNPattern.MatchString(value)
+			case "Service":
+				valid = servicePattern.MatchString(value)
+			case "CanonicalUser":
+				valid = canonicalIDPattern.MatchString(value)
+			case "Federated":
+				valid = value != ""
+			default:
+				problems = append(problems, fmt.Sprintf("%s has unknown principal type %q", key, kind))
+				continue
+			}
+			if !valid {
+				problems = append(problems, fmt.Sprintf("%s.%s %q is not a valid principal", key, kind, value))
+			}
+		}
+	}
+	return problems
+}
+
+// validateResource 检查资源 ARN，并确认它指向当前桶
+func validateResource(bucketName, key, resource string) []string {
+	if resource == "*" {
+		return nil
+	}
+	match := s3ARNPattern.FindStringSubmatch(resource)
+	if match == nil {
+		return []string{fmt.Sprintf("%s %q is not an S3 ARN like \"arn:aws:s3:::%s/*\"", key, resource, bucketName)}
+	}
+	if bucketName != "" {
+		if ok, _ := path.Match(match[2], bucketName); !ok {
+			return []string{fmt.Sprintf("%s %q refers to bucket %q instead of %q", key, resource, match[2], bucketName)}
+		}
+	}
+	return nil
+}
+
+// exactlyOne 要求语句中恰好出现 a、b 中的一个，返回出现的键和值
+func exactlyOne(statement map[string]json.RawMessage, a, b string) (string, json.RawMessage, error) {
+	rawA, okA := statement[a]
+	rawB, okB := statement[b]
+	switch {
+	case okA && okB:
+		return "", nil, fmt.Errorf("must not contain both %s and %s", a, b)
+	case okA:
+		return a, rawA, nil
+	case okB:
+		return b, rawB, nil
+	default:
+		return "", nil, fmt.Errorf("must contain %s or %s", a, b)
+	}
+}
+
+// stringOrList 解析字符串或字符串数组
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("must be a string or an array of strings")
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("must not be empty")
+	}
+	return list, nil
+}
+
+// isWildcardPrincipal 判断 Principal 是否为 "*" 或 {"AWS": "*"}
+func isWildcardPrincipal(raw json.RawMessage) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == "*"
+	}
+	var principal map[string]json.RawMessage
+	if json.Unmarshal(raw, &principal) != nil || len(principal) != 1 {
+		return false
+	}
+	values, err := stringOrList(principal["AWS"])
+	return err == nil && len(values) == 1 && values[0] == "*"
+}
+
+func containsAny(list []string, values ...string) bool {
+	for _, item := range list {
+		for _, value := range values {
+			if item == value {
+				return true
+			}
+		}
+	}
+	return false
+}
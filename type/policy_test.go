@@ -0,0 +1,112 @@
+package nodes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     []string // substrings of the expected problems, empty when valid
+	}{
+		{
+			name: "public read",
+			document: `{"Version": "2012-10-17", "Statement": [{"Sid": "PublicRead", "Effect": "Allow", "Principal": "*",
+				"Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*"}]}`,
+		},
+		{
+			name: "single statement object with account principals",
+			document: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": ["123456789012", "arn:aws:iam::123456789012:role/app"]},
+				"Action": ["s3:ListBucket", "s3:Get*"], "Resource": ["arn:aws:s3:::photos", "arn:aws:s3:::photos/*"]}}`,
+		},
+		{
+			name: "deny with condition is not a lockout",
+			document: `{"Statement": [{"Effect": "Deny", "Principal": "*", "Action": "s3:*", "Resource": "arn:aws:s3:::photos/*",
+				"Condition": {"Bool": {"aws:SecureTransport": "false"}}}]}`,
+		},
+		{
+			name:     "deny of some actions is not a lockout",
+			document: `{"Statement": [{"Effect": "Deny", "Principal": "*", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::photos/*"}]}`,
+		},
+		{
+			name: "wildcard bucket resource",
+			document: `{"Statement": [{"Effect": "Allow", "Principal": {"Service": "logging.s3.amazonaws.com"}, "Action": "s3:PutObject",
+				"Resource": "arn:aws:s3:::pho*/*"}]}`,
+		},
+
+		{name: "not JSON", document: `{"Statement": [`, want: []string{"not a valid JSON object"}},
+		{name: "no statement", document: `{"Version": "2012-10-17"}`, want: []string{"no Statement"}},
+		{
+			name:     "bad version and unknown element",
+			document: `{"Version": "2020-01-01", "Policy": 1, "Statement": []}`,
+			want:     []string{"Version must be", "unknown top-level element \"Policy\"", "must not be empty"},
+		},
+		{
+			name:     "missing effect and resource",
+			document: `{"Statement": [{"Sid": "s1", "Principal": "*", "Action": "s3:GetObject"}]}`,
+			want:     []string{`statement "s1": Effect must be`, `statement "s1": must contain Resource or NotResource`},
+		},
+		{
+			name: "non-S3 action",
+			document: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": ["ec2:RunInstances", "GetObject"],
+				"Resource": "arn:aws:s3:::photos/*"}]}`,
+			want: []string{`"ec2:RunInstances" is not an S3 action`, `"GetObject" is not of the form`},
+		},
+		{
+			name: "resource of another bucket",
+			document: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject",
+				"Resource": "arn:aws:s3:::other/*"}]}`,
+			want: []string{`refers to bucket "other" instead of "photos"`},
+		},
+		{
+			name: "invalid principals",
+			document: `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "12345", "Group": "x"}, "Action": "s3:GetObject",
+				"Resource": "arn:aws:s3:::photos/*"}]}`,
+			want: []string{`Principal.AWS "12345" is not a valid principal`, `unknown principal type "Group"`},
+		},
+		{
+			name:     "both Action and NotAction",
+			document: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "NotAction": "s3:PutObject", "Resource": "*"}]}`,
+			want:     []string{"must not contain both Action and NotAction"},
+		},
+
+		{
+			name:     "lockout",
+			document: `{"Statement": [{"Effect": "Deny", "Principal": "*", "Action": "s3:*", "Resource": "arn:aws:s3:::photos/*"}]}`,
+			want:     []string{"lock you out"},
+		},
+		{
+			name:     "lockout with AWS wildcard principal",
+			document: `{"Statement": [{"Effect": "Deny", "Principal": {"AWS": "*"}, "Action": "*", "Resource": "arn:aws:s3:::photos"}]}`,
+			want:     []string{"lock you out"},
+		},
+		{
+			name:     "lockout with differently cased action",
+			document: `{"Statement": [{"Effect": "Deny", "Principal": "*", "Action": ["S3:*"], "Resource": "arn:aws:s3:::photos/*"}]}`,
+			want:     []string{"lock you out"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := ValidatePolicy("photos", tt.document)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("got problems %q, want %d matching %q", problems, len(tt.want), tt.want)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, problem := range problems {
+					if strings.Contains(problem, want) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("no problem mentions %q: %q", want, problems)
+				}
+			}
+		})
+	}
+}
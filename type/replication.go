@@ -0,0 +1,100 @@
+package nodes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReplicationRule 桶复制规则
+type ReplicationRule struct {
+	ID                      string `json:"id"`                      // 规则 ID
+	Priority                int64  `json:"priority"`                // 优先级，多条规则匹配同一对象时数值大的优先
+	Status                  string `json:"status"`                  // Enabled / Disabled
+	Prefix                  string `json:"prefix"`                  // 对象键前缀过滤
+	Tags                    []Tag  `json:"tags"`                    // 对象标签过滤
+	DestinationBucket       string `json:"destinationBucket"`       // 目标桶 ARN，也可以只填桶名称
+	StorageClass            string `json:"storageClass"`            // 目标存储类型，为空时沿用源对象
+	DeleteMarkerReplication bool   `json:"deleteMarkerReplication"` // 是否复制删除标记
+
+	DestinationAccount      string `json:"destinationAccount"`      // 目标桶所属账号 ID，跨账号复制时填写
+	OwnerOverride           bool   `json:"ownerOverride"`           // 将副本所有权转给目标账号，需要填写 DestinationAccount
+	ReplicaKMSKeyID         string `json:"replicaKmsKeyId"`         // 目标桶中加密副本使用的 KMS 密钥
+	ReplicateKMSEncrypted   bool   `json:"replicateKmsEncrypted"`   // 是否复制 SSE-KMS 加密的对象，需要填写 ReplicaKMSKeyID
+	ReplicaModifications    bool   `json:"replicaModifications"`    // 是否将副本上的元数据修改同步回来
+	ExistingObjects         bool   `json:"existingObjects"`         // 是否复制规则创建前已存在的对象
+	ReplicationTimeMinutes  int64  `json:"replicationTimeMinutes"`  // 复制时间控制(RTC)的目标分钟数，0 表示未启用
+	Metrics                 bool   `json:"metrics"`                 // 是否启用复制指标，启用 RTC 时必须启用
+	MetricsThresholdMinutes int64  `json:"metricsThresholdMinutes"` // 复制指标的延迟阈值(分钟)，0 表示不设置
+}
+
+// ReplicationConfig 桶复制配置
+type ReplicationConfig struct {
+	Role  string            `json:"role"`  // 复制使用的 IAM 角色 ARN(MinIO 为复制目标 ARN)
+	Rules []ReplicationRule `json:"rules"` // 复制规则
+}
+
+// Enabled 是否有启用的复制规则
+func (c ReplicationConfig) Enabled() bool {
+	for _, rule := range c.Rules {
+		if rule.Status == RuleEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 检查复制配置
+func (c ReplicationConfig) Validate() error {
+	if c.Role == "" {
+		return fmt.Errorf("replication needs a role ARN")
+	}
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("replication needs at least one rule")
+	}
+	ids := make(map[string]bool)
+	priorities := make(map[int64]bool)
+	for i, rule := range c.Rules {
+		name := fmt.Sprintf("rule %d", i+1)
+		if rule.ID != "" {
+			if ids[rule.ID] {
+				return fmt.Errorf("duplicate replication rule ID %q", rule.ID)
+			}
+			ids[rule.ID] = true
+			name = fmt.Sprintf("rule %s", rule.ID)
+		}
+		if priorities[rule.Priority] {
+			return fmt.Errorf("%s: priority %d is used by another rule", name, rule.Priority)
+		}
+		priorities[rule.Priority] = true
+		if rule.Status != RuleEnabled && rule.Status != RuleDisabled {
+			return fmt.Errorf("%s: status must be %s or %s", name, RuleEnabled, RuleDisabled)
+		}
+		if rule.DestinationBucket == "" {
+			return fmt.Errorf("%s: destination bucket is required", name)
+		}
+		if err := ValidateTags(rule.Tags, MaxBucketTags); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if rule.OwnerOverride && rule.DestinationAccount == "" {
+			return fmt.Errorf("%s: changing replica ownership needs the destination account ID", name)
+		}
+		if rule.ReplicateKMSEncrypted && rule.ReplicaKMSKeyID == "" {
+			return fmt.Errorf("%s: replicating SSE-KMS objects needs a replica KMS key", name)
+		}
+		if rule.ReplicationTimeMinutes < 0 || rule.MetricsThresholdMinutes < 0 {
+			return fmt.Errorf("%s: replication time and metrics threshold must not be negative", name)
+		}
+		if rule.ReplicationTimeMinutes > 0 && !rule.Metrics {
+			return fmt.Errorf("%s: replication time control needs replication metrics enabled", name)
+		}
+	}
+	return nil
+}
+
+// DestinationARN 返回目标桶 ARN，只填写了桶名称时补全为 arn:aws:s3:::<bucket>
+func (r ReplicationRule) DestinationARN() string {
+	if strings.HasPrefix(r.DestinationBucket, "arn:") {
+		return r.DestinationBucket
+	}
+	return "arn:aws:s3:::" + r.DestinationBucket
+}
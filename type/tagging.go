@@ -0,0 +1,39 @@
+package nodes
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// 标签数量上限
+const (
+	MaxBucketTags = 50
+	MaxObjectTags = 10
+)
+
+// Tag 标签键值对
+type Tag struct {
+	Key   string `json:"key"`   // 标签键
+	Value string `json:"value"` // 标签值
+}
+
+// ValidateTags 检查标签数量、长度和重复键，limit 为 MaxBucketTags 或 MaxObjectTags
+func ValidateTags(tags []Tag, limit int) error {
+	if len(tags) > limit {
+		return fmt.Errorf("at most %d tags are allowed, got %d", limit, len(tags))
+	}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if tag.Key == "" || utf8.RuneCountInString(tag.Key) > 128 {
+			return fmt.Errorf("tag key %q must be between 1 and 128 characters long", tag.Key)
+		}
+		if utf8.RuneCountInString(tag.Value) > 256 {
+			return fmt.Errorf("value of tag %q must be at most 256 characters long", tag.Key)
+		}
+		if seen[tag.Key] {
+			return fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		seen[tag.Key] = true
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package nodes
+
+import "fmt"
+
+// WebsiteRedirect 重定向目标
+type WebsiteRedirect struct {
+	HostName             string `json:"hostName"`             // 目标主机名
+	Protocol             string `json:"protocol"`             // http / https，为空时沿用原请求协议
+	HttpRedirectCode     string `json:"httpRedirectCode"`     // 重定向状态码，如 301、302，仅路由规则使用
+	ReplaceKeyPrefixWith string `json:"replaceKeyPrefixWith"` // 替换匹配到的键前缀，仅路由规则使用
+	ReplaceKeyWith       string `json:"replaceKeyWith"`       // 替换整个键，仅路由规则使用
+}
+
+// RoutingCondition 路由规则的匹配条件
+type RoutingCondition struct {
+	KeyPrefixEquals             string `json:"keyPrefixEquals"`             // 请求键前缀
+	HttpErrorCodeReturnedEquals string `json:"httpErrorCodeReturnedEquals"` // 返回的 HTTP 错误码，如 404
+}
+
+// RoutingRule 静态网站路由规则
+type RoutingRule struct {
+	Condition RoutingCondition `json:"condition"` // 匹配条件，全部为空表示匹配所有请求
+	Redirect  WebsiteRedirect  `json:"redirect"`  // 重定向目标
+}
+
+// WebsiteConfig 桶的静态网站配置。RedirectAllTo 与 IndexDocument 二选一
+type WebsiteConfig struct {
+	Enabled       bool             `json:"enabled"`       // 是否启用静态网站，读取时有效
+	IndexDocument string           `json:"indexDocument"` // 索引文档，如 index.html
+	ErrorDocument string           `json:"errorDocument"` // 错误文档，如 404.html
+	RedirectAllTo *WebsiteRedirect `json:"redirectAllTo"` // 将所有请求重定向到其他主机
+	RoutingRules  []RoutingRule    `json:"routingRules"`  // 路由规则
+}
+
+// Validate 检查静态网站配置
+func (c WebsiteConfig) Validate() error {
+	if c.RedirectAllTo != nil {
+		if c.IndexDocument != "" || c.ErrorDocument != "" || len(c.RoutingRules) > 0 {
+			return fmt.Errorf("redirect-all cannot be combined with index/error documents or routing rules")
+		}
+		if c.RedirectAllTo.HostName == "" {
+			return fmt.Errorf("redirect-all needs a host name")
+		}
+		return checkProtocol(c.RedirectAllTo.Protocol)
+	}
+	if c.IndexDocument == "" {
+		return fmt.Errorf("an index document is required unless all requests are redirected")
+	}
+	for i, rule := range c.RoutingRules {
+		r := rule.Redirect
+		if r.ReplaceKeyPrefixWith != "" && r.ReplaceKeyWith != "" {
+			return fmt.Errorf("routing rule %d: replace key and replace key prefix cannot both be set", i+1)
+		}
+		if r.HostName == "" && r.HttpRedirectCode == "" && r.Protocol == "" &&
+			r.ReplaceKeyPrefixWith == "" && r.ReplaceKeyWith == "" {
+			return fmt.Errorf("routing rule %d: redirect must change at least one of host, protocol, code or key", i+1)
+		}
+		if err := checkProtocol(r.Protocol); err != nil {
+			return fmt.Errorf("routing rule %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+func checkProtocol(protocol string) error {
+	if protocol != "" && protocol != "http" && protocol != "https" {
+		return fmt.Errorf("redirect protocol must be http or https")
+	}
+	return nil
+}
+
+// DeployResult 部署静态网站的结果
+type DeployResult struct {
+	Endpoint  string   `json:"endpoint"`  // 网站访问地址
+	Uploaded  int      `json:"uploaded"`  // 上传的文件数
+	Unchanged int      `json:"unchanged"` // 内容未变而跳过的文件数
+	Deleted   int      `json:"deleted"`   // 删除的过期文件数
+	Stale     []string `json:"stale"`     // 本地已不存在的远端对象键，未要求删除时只列出
+	Failed    []string `json:"failed"`    // 上传或删除失败的对象键
+}
@@ -0,0 +1,212 @@
+// Package vault 使用口令派生的密钥加密保存在配置文件中的密钥
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// sealedPrefix 标记已加密的字段
+const sealedPrefix = "vault:v1:"
+
+// checkPlaintext 用于校验口令是否正确的已知明文
+const checkPlaintext = "srsc-vault-check"
+
+// DefaultAutoLockMinutes 默认的自动锁定时间(分钟)
+const DefaultAutoLockMinutes = 15
+
+var (
+	// ErrLocked 保险库处于锁定状态
+	ErrLocked = errors.New("vault is locked")
+	// ErrWrongPassphrase 口令错误
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+)
+
+// Header 保存在配置文件中的保险库参数，不包含任何密钥
+type Header struct {
+	KDF             string `json:"KDF"`             // 目前固定为 argon2id
+	Salt            string `json:"Salt"`            // base64 编码的随机盐
+	Time            uint32 `json:"Time"`            // argon2id 迭代次数
+	Memory          uint32 `json:"Memory"`          // argon2id 内存(KiB)
+	Threads         uint8  `json:"Threads"`         // argon2id 并行度
+	Check           string `json:"Check"`           // 加密后的校验串
+	AutoLockMinutes int    `json:"AutoLockMinutes"` // 空闲多少分钟后自动锁定，0 表示不自动锁定
+}
+
+// NewHeader 为 passphrase 生成新的保险库参数，并返回派生出的密钥
+func NewHeader(passphrase string) (*Header, []byte, error) {
+	if passphrase == "" {
+		return nil, nil, errors.New("passphrase must not be empty")
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	h := &Header{
+		KDF:             "argon2id",
+		Salt:            base64.StdEncoding.EncodeToString(salt),
+		Time:            3,
+		Memory:          64 * 1024,
+		Threads:         4,
+		AutoLockMinutes: DefaultAutoLockMinutes,
+	}
+	key := h.deriveKey(passphrase, salt)
+	check, err := Seal(key, checkPlaintext, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	h.Check = check
+	return h, key, nil
+}
+
+// Unlock 从 passphrase 派生密钥并校验
+func (h *Header) Unlock(passphrase string) ([]byte, error) {
+	if h.KDF != "argon2id" {
+		return nil, fmt.Errorf("unsupported key derivation function %q", h.KDF)
+	}
+	salt, err := base64.StdEncoding.DecodeString(h.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault salt: %v", err)
+	}
+	key := h.deriveKey(passphrase, salt)
+	if check, err := Open(key, h.Check, ""); err != nil || check != checkPlaintext {
+		return nil, ErrWrongPassphrase
+	}
+	return key, nil
+}
+
+func (h *Header) deriveKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, h.Time, h.Memory, h.Threads, chacha20poly1305.KeySize)
+}
+
+// IsSealed 判断字段是否为加密后的值
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// Seal 使用 XChaCha20-Poly1305 加密 plaintext，aad 绑定到密文(通常是节点 ID)
+func Seal(key []byte, plaintext, aad string) (string, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open 解密 Seal 生成的值
+func Open(key []byte, value, aad string) (string, error) {
+	if !IsSealed(value) {
+		return "", errors.New("value is not sealed")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed value: %v", err)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", err
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", errors.New("sealed value too short")
+	}
+	plaintext, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", errors.New("unable to decrypt sealed value")
+	}
+	return string(plaintext), nil
+}
+
+// Keyring 在内存中保存解锁后的密钥，空闲超时后自动锁定
+type Keyring struct {
+	mu      sync.Mutex
+	key     []byte
+	timeout time.Duration
+	timer   *time.Timer
+	onLock  func()
+}
+
+// NewKeyring 创建处于锁定状态的 Keyring，onLock 在每次锁定时调用
+func NewKeyring(onLock func()) *Keyring {
+	return &Keyring{onLock: onLock}
+}
+
+// Unlock 保存密钥并开始计时
+func (k *Keyring) Unlock(key []byte, autoLockMinutes int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.key = key
+	k.timeout = time.Duration(autoLockMinutes) * time.Minute
+	k.resetTimer()
+}
+
+// Lock 清除内存中的密钥
+func (k *Keyring) Lock() {
+	k.mu.Lock()
+	k.lock()
+	k.mu.Unlock()
+	if k.onLock != nil {
+		k.onLock()
+	}
+}
+
+func (k *Keyring) lock() {
+	for i := range k.key {
+		k.key[i] = 0
+	}
+	k.key = nil
+	if k.timer != nil {
+		k.timer.Stop()
+		k.timer = nil
+	}
+}
+
+// Locked 是否处于锁定状态
+func (k *Keyring) Locked() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.key == nil
+}
+
+// SetAutoLock 修改自动锁定时间，0 表示不自动锁定
+func (k *Keyring) SetAutoLock(minutes int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.timeout = time.Duration(minutes) * time.Minute
+	if k.key != nil {
+		k.resetTimer()
+	}
+}
+
+// Key 返回当前密钥并重置空闲计时，锁定时返回 ErrLocked
+func (k *Keyring) Key() ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.key == nil {
+		return nil, ErrLocked
+	}
+	k.resetTimer()
+	return append([]byte(nil), k.key...), nil
+}
+
+func (k *Keyring) resetTimer() {
+	if k.timer != nil {
+		k.timer.Stop()
+		k.timer = nil
+	}
+	if k.timeout > 0 {
+		k.timer = time.AfterFunc(k.timeout, k.Lock)
+	}
+}
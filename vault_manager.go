@@ -0,0 +1,139 @@
+package main
+
+import (
+	"SRSC-Client/vault"
+	"errors"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// secretKeyring 保存解锁后的保险库密钥，锁定时清空客户端缓存并通知前端
+var secretKeyring = vault.NewKeyring(func() {
+	s3Clients.Reset()
+	if ContextX != nil {
+		runtime.EventsEmit(ContextX, "vault:locked")
+	}
+})
+
+// VaultStatus 保险库状态
+type VaultStatus struct {
+	Enabled         bool `json:"enabled"`         // 是否启用保险库
+	Locked          bool `json:"locked"`          // 是否处于锁定状态
+	AutoLockMinutes int  `json:"autoLockMinutes"` // 自动锁定时间(分钟)
+}
+
+// GetVaultStatus 返回保险库状态
+func (a *S3Manager) GetVaultStatus() VaultStatus {
+	cfg, err := readConfig()
+	if err != nil || cfg.Vault == nil {
+		return VaultStatus{}
+	}
+	return VaultStatus{
+		Enabled:         true,
+		Locked:          secretKeyring.Locked(),
+		AutoLockMinutes: cfg.Vault.AutoLockMinutes,
+	}
+}
+
+// EnableVault 启用保险库，并将现有配置中的明文密钥一次性加密
+func (a *S3Manager) EnableVault(passphrase string) error {
+	var header *vault.Header
+	var key []byte
+	err := updateConfig(func(cfg *configFile) error {
+		if cfg.Vault != nil {
+			return errors.New("vault is already enabled")
+		}
+		var err error
+		if header, key, err = vault.NewHeader(passphrase); err != nil {
+			return err
+		}
+		sealed, err := sealSecrets(key, cfg.Nodes)
+		if err != nil {
+			return err
+		}
+		cfg.Vault = header
+		cfg.Nodes = sealed
+		return nil
+	})
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to enable vault: "+err.Error())
+		return err
+	}
+	secretKeyring.Unlock(key, header.AutoLockMinutes)
+	runtime.LogDebug(ContextX, "Vault enabled, secret keys encrypted")
+	return nil
+}
+
+// DisableVault 关闭保险库，将密钥以明文写回配置文件
+func (a *S3Manager) DisableVault(passphrase string) error {
+	err := updateConfig(func(cfg *configFile) error {
+		if cfg.Vault == nil {
+			return errors.New("vault is not enabled")
+		}
+		key, err := cfg.Vault.Unlock(passphrase)
+		if err != nil {
+			return err
+		}
+		opened, err := openSecrets(key, cfg.Nodes)
+		if err != nil {
+			return err
+		}
+		cfg.Vault = nil
+		cfg.Nodes = opened
+		return nil
+	})
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to disable vault: "+err.Error())
+		return err
+	}
+	secretKeyring.Lock()
+	runtime.LogDebug(ContextX, "Vault disabled, secret keys stored in plaintext")
+	return nil
+}
+
+// UnlockVault 使用口令解锁保险库
+func (a *S3Manager) UnlockVault(passphrase string) error {
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Vault == nil {
+		return errors.New("vault is not enabled")
+	}
+	key, err := cfg.Vault.Unlock(passphrase)
+	if err != nil {
+		runtime.LogError(ContextX, "Failed to unlock vault: "+err.Error())
+		return err
+	}
+	secretKeyring.Unlock(key, cfg.Vault.AutoLockMinutes)
+	runtime.LogDebug(ContextX, "Vault unlocked")
+	return nil
+}
+
+// LockVault 立即锁定保险库
+func (a *S3Manager) LockVault() {
+	secretKeyring.Lock()
+	runtime.LogDebug(ContextX, "Vault locked")
+}
+
+// SetVaultAutoLock 设置空闲自动锁定时间(分钟)，0 表示不自动锁定；需先解锁
+func (a *S3Manager) SetVaultAutoLock(minutes int) error {
+	if minutes < 0 {
+		return errors.New("auto-lock minutes must not be negative")
+	}
+	if secretKeyring.Locked() {
+		return vault.ErrLocked
+	}
+	err := updateConfig(func(cfg *configFile) error {
+		if cfg.Vault == nil {
+			return errors.New("vault is not enabled")
+		}
+		cfg.Vault.AutoLockMinutes = minutes
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	secretKeyring.SetAutoLock(minutes)
+	return nil
+}